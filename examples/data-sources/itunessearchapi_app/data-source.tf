@@ -0,0 +1,25 @@
+# Look up an app by bundle ID
+data "itunessearchapi_app" "pages" {
+  bundle_id = "com.apple.Pages"
+  country   = "gb"
+}
+
+# Look up an app by App Store URL
+data "itunessearchapi_app" "messenger" {
+  app_store_url = "https://apps.apple.com/gb/app/messenger/id1480068668"
+}
+
+# Fail the plan until the App Store has version 14.2 or newer
+data "itunessearchapi_app" "pages_gate" {
+  bundle_id            = "com.apple.Pages"
+  minimum_version      = "14.2"
+  supported_os_version = "17.0"
+}
+
+output "pages_version" {
+  value = {
+    version            = data.itunessearchapi_app.pages.version
+    minimum_os_version = data.itunessearchapi_app.pages.minimum_os_version
+    released           = data.itunessearchapi_app.pages.current_version_release_date
+  }
+}
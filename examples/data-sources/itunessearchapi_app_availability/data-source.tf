@@ -0,0 +1,19 @@
+# Check that apps are live in every target storefront
+data "itunessearchapi_app_availability" "release" {
+  bundle_ids = ["com.apple.Pages", "com.apple.Keynote"]
+  countries  = ["us", "gb", "de", "jp"]
+}
+
+output "not_live" {
+  value = {
+    for app in data.itunessearchapi_app_availability.release.apps :
+    app.bundle_id => app.missing_in if !app.available_everywhere
+  }
+}
+
+output "pages_prices" {
+  value = {
+    for country, listing in data.itunessearchapi_app_availability.release.apps[0].storefronts :
+    country => "${listing.formatted_price} (${listing.currency})"
+  }
+}
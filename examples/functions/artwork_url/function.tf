@@ -0,0 +1,8 @@
+# Request a 1024x1024 WebP icon for an app
+data "itunessearchapi_app" "pages" {
+  bundle_id = "com.apple.Pages"
+}
+
+output "pages_icon" {
+  value = provider::itunessearchapi::artwork_url(data.itunessearchapi_app.pages.artwork_url, 1024, "webp")
+}
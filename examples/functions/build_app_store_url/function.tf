@@ -0,0 +1,7 @@
+# Link to an app in several storefronts
+output "pages_urls" {
+  value = {
+    for country in ["us", "gb", "de"] :
+    country => provider::itunessearchapi::build_app_store_url(country, "pages", 361309726)
+  }
+}
@@ -0,0 +1,11 @@
+# Only deploy once the App Store has caught up with the pinned version
+data "itunessearchapi_app" "pages" {
+  bundle_id = "com.apple.Pages"
+
+  lifecycle {
+    postcondition {
+      condition     = provider::itunessearchapi::compare_versions(self.version, "14.2") >= 0
+      error_message = "Pages ${self.version} is older than 14.2."
+    }
+  }
+}
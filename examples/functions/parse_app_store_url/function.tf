@@ -0,0 +1,9 @@
+# Split an App Store URL into its storefront, slug, and track ID
+locals {
+  pages = provider::itunessearchapi::parse_app_store_url("https://apps.apple.com/gb/app/pages/id361309726")
+}
+
+data "itunessearchapi_app" "pages" {
+  track_id = local.pages.id
+  country  = local.pages.country
+}
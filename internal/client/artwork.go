@@ -0,0 +1,41 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FetchArtwork downloads an artwork image using the client's retry and backoff
+// behaviour. Artwork is served from Apple's CDN rather than the Search API, so
+// these requests do not draw from the API rate limiter.
+func (c *Client) FetchArtwork(ctx context.Context, imageURL string) ([]byte, error) {
+	return c.observe(ctx, OperationArtwork, imageURL, func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		req.Header.Set("User-Agent", "Terraform-Provider-iTunesSearchAPI")
+		req.Header.Set("Accept", "image/*")
+
+		resp, err := c.doWithRetry(ctx, req, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading image: %w", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		imageData, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error reading image: %w", err)
+		}
+
+		return imageData, nil
+	})
+}
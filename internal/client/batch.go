@@ -0,0 +1,233 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+// Selectors that lookup batching can merge. Only selectors whose values are
+// echoed in the results are batched, so that rows can be split back to the
+// request that asked for them.
+const (
+	batchSelectorID          = "id"
+	batchSelectorBundleID    = "bundleId"
+	batchSelectorAMGArtistID = "amgArtistId"
+)
+
+// lookupBatchKey identifies lookups that can share one request.
+type lookupBatchKey struct {
+	selector string
+	media    string
+	entity   string
+	country  string
+}
+
+// batchKey returns the batch a lookup belongs to. Only lookups using a single
+// echoed selector and no sort order are batched. A limit, which applies to
+// the merged request as a whole, is only allowed when it matches the number
+// of values and no entity expands the results, so that it cannot cut rows.
+func (c *Client) batchKey(req LookupRequest) (lookupBatchKey, bool) {
+	if req.Sort != "" {
+		return lookupBatchKey{}, false
+	}
+	if req.Limit != 0 && (req.Entity != "" || req.Limit != int64(lookupSize(req))) {
+		return lookupBatchKey{}, false
+	}
+	if len(req.AMGAlbumIDs) > 0 || len(req.AMGVideoIDs) > 0 || len(req.UPCs) > 0 || len(req.ISBNs) > 0 {
+		return lookupBatchKey{}, false
+	}
+
+	var selectors []string
+	if len(req.IDs) > 0 {
+		selectors = append(selectors, batchSelectorID)
+	}
+	if len(req.BundleIDs) > 0 {
+		selectors = append(selectors, batchSelectorBundleID)
+	}
+	if len(req.AMGArtistIDs) > 0 {
+		selectors = append(selectors, batchSelectorAMGArtistID)
+	}
+	if len(selectors) != 1 {
+		return lookupBatchKey{}, false
+	}
+
+	return lookupBatchKey{
+		selector: selectors[0],
+		media:    req.Media,
+		entity:   req.Entity,
+		country:  c.country(req.Country),
+	}, true
+}
+
+// lookupSize returns the number of selector values in a lookup.
+func lookupSize(req LookupRequest) int {
+	return len(req.IDs) + len(req.BundleIDs) + len(req.AMGArtistIDs) +
+		len(req.AMGAlbumIDs) + len(req.AMGVideoIDs) + len(req.UPCs) + len(req.ISBNs)
+}
+
+// lookupBatcher merges lookups that arrive within a short window into one
+// request per selector, media, entity, and country, and splits the results back to
+// each caller.
+type lookupBatcher struct {
+	client  *Client
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[lookupBatchKey]*lookupBatch
+}
+
+// lookupBatch is a merged lookup collecting callers until it is sent.
+type lookupBatch struct {
+	req     LookupRequest
+	size    int
+	timer   *time.Timer
+	done    chan struct{}
+	resp    *ContentResponse
+	err     error
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// newLookupBatcher creates a batcher that holds lookups for window before
+// sending them.
+func newLookupBatcher(c *Client, window time.Duration) *lookupBatcher {
+	return &lookupBatcher{
+		client:  c,
+		window:  window,
+		pending: make(map[lookupBatchKey]*lookupBatch),
+	}
+}
+
+// lookup adds req to the pending batch for key, starting one if needed, and
+// returns the caller's share of the merged response. A batch is sent when its
+// window ends or when req would take it past the API's limit on values per
+// lookup. The merged request is only cancelled once every caller has given up.
+func (b *lookupBatcher) lookup(ctx context.Context, key lookupBatchKey, req LookupRequest) (*ContentResponse, error) {
+	size := lookupSize(req)
+
+	b.mu.Lock()
+	batch := b.pending[key]
+	if batch != nil && batch.size+size > common.MaxLookupBatchSize {
+		b.sendLocked(key, batch)
+		batch = nil
+	}
+	if batch == nil {
+		batchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		batch = &lookupBatch{
+			req:    LookupRequest{Media: req.Media, Entity: req.Entity, Country: req.Country},
+			done:   make(chan struct{}),
+			ctx:    batchCtx,
+			cancel: cancel,
+		}
+		b.pending[key] = batch
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.sendLocked(key, batch)
+		})
+	}
+	batch.req.IDs = append(batch.req.IDs, req.IDs...)
+	batch.req.BundleIDs = append(batch.req.BundleIDs, req.BundleIDs...)
+	batch.req.AMGArtistIDs = append(batch.req.AMGArtistIDs, req.AMGArtistIDs...)
+	batch.size += size
+	if req.Limit != 0 || batch.req.Limit != 0 {
+		batch.req.Limit = int64(batch.size)
+	}
+	batch.waiters++
+	if batch.size >= common.MaxLookupBatchSize {
+		b.sendLocked(key, batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return splitLookupResponse(batch.resp, batch.err, req)
+	case <-ctx.Done():
+		b.mu.Lock()
+		batch.waiters--
+		if batch.waiters == 0 {
+			batch.cancel()
+			if b.pending[key] == batch {
+				batch.timer.Stop()
+				delete(b.pending, key)
+			}
+		}
+		b.mu.Unlock()
+		return nil, canceledError(ctx)
+	}
+}
+
+// sendLocked removes the batch from the pending set, if it is still there,
+// and sends it. Callers must hold b.mu.
+func (b *lookupBatcher) sendLocked(key lookupBatchKey, batch *lookupBatch) {
+	if b.pending[key] != batch {
+		return
+	}
+	delete(b.pending, key)
+	batch.timer.Stop()
+
+	if logger := b.client.logger; logger != nil {
+		logger.LogEvent(batch.ctx, "Sending batched lookup", map[string]any{
+			"selector": key.selector,
+			"media":    key.media,
+			"entity":   key.entity,
+			"country":  key.country,
+			"values":   batch.size,
+			"callers":  batch.waiters,
+		})
+	}
+
+	go func() {
+		defer batch.cancel()
+		batch.resp, batch.err = b.client.lookup(batch.ctx, batch.req)
+		close(batch.done)
+	}()
+}
+
+// splitLookupResponse returns the rows of a merged lookup that answer req,
+// with a NotFoundError listing any of its values that had no match. Without
+// an entity, an iTunes ID is only answered by the row it identifies, so that
+// an app requested by one caller is not also returned to a caller asking for
+// its developer.
+func splitLookupResponse(resp *ContentResponse, err error, req LookupRequest) (*ContentResponse, error) {
+	var notFound *NotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	answersID := func(r ContentResult, id int64) bool {
+		if req.Entity == "" {
+			return NewResult(r).ID() == id
+		}
+		return matchesID(r, id)
+	}
+
+	result := &ContentResponse{}
+	if resp != nil {
+		for _, r := range resp.Results {
+			if slices.ContainsFunc(req.IDs, func(id int64) bool { return answersID(r, id) }) ||
+				slices.ContainsFunc(req.BundleIDs, func(bundleID string) bool { return matchesBundleID(r, bundleID) }) ||
+				slices.ContainsFunc(req.AMGArtistIDs, func(id int64) bool { return matchesAMGArtistID(r, id) }) {
+				result.Results = append(result.Results, r)
+			}
+		}
+	}
+
+	missing := &NotFoundError{
+		MissingIDs:       missingValues(req.IDs, result.Results, matchesID),
+		MissingBundleIDs: missingValues(req.BundleIDs, result.Results, matchesBundleID),
+		MissingAMGIDs:    missingValues(req.AMGArtistIDs, result.Results, matchesAMGArtistID),
+	}
+	if !missing.empty() {
+		return result, missing
+	}
+	return result, nil
+}
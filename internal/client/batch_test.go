@@ -0,0 +1,224 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
+
+// newBatchingClient returns a client for the fake server with lookup batching
+// enabled and a rate limit generous enough not to slow the tests.
+func newBatchingClient(serverURL string) *Client {
+	return NewClientWithConfig(Config{
+		BaseURL:           serverURL,
+		RateLimitRequests: 1000,
+		RateLimitDuration: time.Second,
+		LookupBatchWindow: 50 * time.Millisecond,
+	})
+}
+
+// lookupConcurrently runs the lookups in parallel and returns their results
+// and errors in order.
+func lookupConcurrently(c *Client, reqs []LookupRequest) ([]*ContentResponse, []error) {
+	results := make([]*ContentResponse, len(reqs))
+	errs := make([]error, len(reqs))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.Lookup(context.Background(), req)
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func TestBatchKey(t *testing.T) {
+	c := NewClientWithConfig(Config{DefaultCountry: "gb"})
+
+	tests := []struct {
+		name   string
+		req    LookupRequest
+		want   lookupBatchKey
+		wantOK bool
+	}{
+		{
+			name:   "bundle id",
+			req:    LookupRequest{BundleIDs: []string{"com.apple.Pages"}},
+			want:   lookupBatchKey{selector: batchSelectorBundleID, country: "gb"},
+			wantOK: true,
+		},
+		{
+			name:   "ids with entity and country",
+			req:    LookupRequest{IDs: []int64{1, 2}, Entity: "song", Country: "us"},
+			want:   lookupBatchKey{selector: batchSelectorID, entity: "song", country: "us"},
+			wantOK: true,
+		},
+		{
+			name:   "aligned limit",
+			req:    LookupRequest{IDs: []int64{1}, Limit: 1},
+			want:   lookupBatchKey{selector: batchSelectorID, country: "gb"},
+			wantOK: true,
+		},
+		{name: "limit that could cut rows", req: LookupRequest{IDs: []int64{1}, Limit: 5}},
+		{name: "limit with entity", req: LookupRequest{IDs: []int64{1}, Entity: "song", Limit: 1}},
+		{name: "sort", req: LookupRequest{AMGArtistIDs: []int64{1}, Sort: "recent"}},
+		{name: "several selectors", req: LookupRequest{IDs: []int64{1}, BundleIDs: []string{"com.apple.Pages"}}},
+		{name: "unechoed selector", req: LookupRequest{UPCs: []string{"720642462928"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.batchKey(tt.req)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("batchKey() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLookup_BatchesConcurrentLookups(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newBatchingClient(server.URL)
+
+	results, errs := lookupConcurrently(c, []LookupRequest{
+		{BundleIDs: []string{"com.apple.Pages"}, Limit: 1},
+		{BundleIDs: []string{"com.apple.Keynote"}},
+		{BundleIDs: []string{"com.example.missing"}},
+	})
+
+	if server.RequestCount() != 1 {
+		t.Errorf("expected 1 request, got %d: %v", server.RequestCount(), server.Requests())
+	}
+	for i, want := range []string{"com.apple.Pages", "com.apple.Keynote"} {
+		if errs[i] != nil {
+			t.Fatalf("lookup %d: unexpected error: %v", i, errs[i])
+		}
+		if len(results[i].Results) != 1 || results[i].Results[0].BundleID != want {
+			t.Errorf("lookup %d: expected only %s, got %+v", i, want, results[i].Results)
+		}
+	}
+
+	var notFound *NotFoundError
+	if !errors.As(errs[2], &notFound) || !slices.Equal(notFound.MissingBundleIDs, []string{"com.example.missing"}) {
+		t.Errorf("expected the missing bundle ID to be reported to its caller, got %v", errs[2])
+	}
+}
+
+func TestLookup_BatchesPerCountryAndSelector(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newBatchingClient(server.URL)
+
+	_, errs := lookupConcurrently(c, []LookupRequest{
+		{BundleIDs: []string{"com.apple.Pages"}, Country: "us"},
+		{BundleIDs: []string{"com.apple.Keynote"}, Country: "us"},
+		{BundleIDs: []string{"com.apple.Pages"}, Country: "gb"},
+		{IDs: []int64{361285480}, Country: "us"},
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("lookup %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if server.RequestCount() != 3 {
+		t.Errorf("expected 3 requests, got %d: %v", server.RequestCount(), server.Requests())
+	}
+}
+
+func TestLookup_BatchSplitsIDsByRow(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newBatchingClient(server.URL)
+
+	results, errs := lookupConcurrently(c, []LookupRequest{
+		{IDs: []int64{1469577723}},
+		{IDs: []int64{909253}},
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("lookup %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if len(results[0].Results) != 1 || results[0].Results[0].WrapperType != WrapperTypeCollection {
+		t.Errorf("expected only the album, got %+v", results[0].Results)
+	}
+	if len(results[1].Results) != 1 || results[1].Results[0].WrapperType != WrapperTypeArtist {
+		t.Errorf("expected only the artist, got %+v", results[1].Results)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected 1 request, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_BatchSentWhenFull(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newBatchingClient(server.URL)
+
+	ids := make([]int64, 150)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	_, errs := lookupConcurrently(c, []LookupRequest{{IDs: ids}, {IDs: ids}})
+	for i, err := range errs {
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) || len(notFound.MissingIDs) != len(ids) {
+			t.Errorf("lookup %d: expected every ID to be missing, got %v", i, err)
+		}
+	}
+
+	if server.RequestCount() != 2 {
+		t.Errorf("expected the batch to be split into 2 requests, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_UnbatchableLookupSentDirectly(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := NewClientWithConfig(Config{BaseURL: server.URL, LookupBatchWindow: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := c.Lookup(ctx, LookupRequest{AMGArtistIDs: []int64{468749}, Entity: "album", Limit: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) == 0 {
+		t.Error("expected results")
+	}
+}
+
+func TestLookup_BatchCallerCanceled(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := NewClientWithConfig(Config{BaseURL: server.URL, LookupBatchWindow: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Lookup(ctx, LookupRequest{BundleIDs: []string{"com.apple.Pages"}}); !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected ErrCanceled, got %v", err)
+	}
+
+	c.batcher.mu.Lock()
+	pending := len(c.batcher.pending)
+	c.batcher.mu.Unlock()
+	if pending != 0 {
+		t.Errorf("expected the abandoned batch to be dropped, %d pending", pending)
+	}
+	if server.RequestCount() != 0 {
+		t.Errorf("expected no requests, got %d", server.RequestCount())
+	}
+}
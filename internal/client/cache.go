@@ -0,0 +1,174 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// cacheFileRegex matches the entry, lock, and temporary files the cache
+// creates, so that sweeping never touches anything else in the directory.
+var cacheFileRegex = regexp.MustCompile(`^[0-9a-f]{64}(\.lock|\.[0-9]+\.tmp)?$`)
+
+// responseCache stores raw API response bodies on disk, keyed by request URL,
+// so that repeated lookups can be shared between Terraform runs and processes.
+type responseCache struct {
+	dir    string
+	ttl    time.Duration
+	bypass bool
+}
+
+// newResponseCache returns a cache rooted at dir, sweeping files left behind by
+// earlier runs. When bypass is true cached entries are never read, but fresh
+// responses are still written back.
+func newResponseCache(dir string, ttl time.Duration, bypass bool) *responseCache {
+	rc := &responseCache{
+		dir:    dir,
+		ttl:    ttl,
+		bypass: bypass,
+	}
+	rc.sweep()
+	return rc
+}
+
+// sweep removes entries, lock files, and temporary files older than the TTL.
+// Lock files are only removed when no other process holds them. Errors are
+// ignored, since a missed file is simply swept on a later run.
+func (rc *responseCache) sweep() {
+	files, err := os.ReadDir(rc.dir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !cacheFileRegex.MatchString(file.Name()) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil || time.Since(info.ModTime()) <= rc.ttl {
+			continue
+		}
+
+		path := filepath.Join(rc.dir, file.Name())
+		if strings.HasSuffix(path, ".lock") {
+			removeUnheldLock(path)
+			continue
+		}
+		_ = os.Remove(path)
+	}
+}
+
+// removeUnheldLock deletes the lock file at path if its lock can be taken
+// without blocking.
+func removeUnheldLock(path string) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	if locked, err := tryLockFile(f); err != nil || !locked {
+		return
+	}
+	_ = os.Remove(path)
+	_ = unlockFile(f)
+}
+
+// key returns the file name used to store the response for the given URL.
+func (rc *responseCache) key(apiURL string) string {
+	sum := sha256.Sum256([]byte(apiURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// read returns the cached body for key if present and younger than the TTL.
+// An expired entry is removed.
+func (rc *responseCache) read(key string) ([]byte, bool) {
+	path := filepath.Join(rc.dir, key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if time.Since(info.ModTime()) > rc.ttl {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// write atomically stores body under key by writing to a temporary file and
+// renaming it into place, so readers never observe a partial entry.
+func (rc *responseCache) write(key string, body []byte) error {
+	tmp, err := os.CreateTemp(rc.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating cache file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(body); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error writing cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error closing cache file: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(rc.dir, key)); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error renaming cache file: %w", err)
+	}
+	return nil
+}
+
+// get returns the body for apiURL from the cache, calling fetch on a miss.
+// A per-entry file lock ensures only one process fetches a given URL at a
+// time; the others wait and then read the entry it wrote. Failures to write
+// the cache are reported through onWriteError but do not fail the request.
+func (rc *responseCache) get(ctx context.Context, apiURL string, fetch func(context.Context) ([]byte, error), onWriteError func(error)) ([]byte, bool, error) {
+	key := rc.key(apiURL)
+	if !rc.bypass {
+		if body, ok := rc.read(key); ok {
+			return body, true, nil
+		}
+	}
+
+	if err := os.MkdirAll(rc.dir, 0o700); err != nil {
+		return nil, false, fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	lock, err := acquireFileLock(ctx, filepath.Join(rc.dir, key+".lock"))
+	if err != nil {
+		return nil, false, fmt.Errorf("error acquiring cache lock: %w", err)
+	}
+	defer lock.release()
+
+	if !rc.bypass {
+		if body, ok := rc.read(key); ok {
+			return body, true, nil
+		}
+	}
+
+	body, err := fetch(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := rc.write(key, body); err != nil && onWriteError != nil {
+		onWriteError(err)
+	}
+	return body, false, nil
+}
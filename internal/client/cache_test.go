@@ -0,0 +1,258 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingServer(t *testing.T, callCount *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(callCount, 1)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"resultCount":1,"results":[{"trackId":%d,"trackName":"Call %d"}]}`, n, n)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCache_ServesFreshEntriesAcrossClients(t *testing.T) {
+	var callCount int32
+	server := newCountingServer(t, &callCount)
+	dir := t.TempDir()
+
+	first := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir})
+	second := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir})
+
+	req := SearchRequest{Term: "jack johnson"}
+	resp1, err := first.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp2, err := second.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if atomic.LoadInt32(&callCount) != 1 {
+		t.Errorf("expected 1 API call, got %d", atomic.LoadInt32(&callCount))
+	}
+	if resp2.Results[0].TrackName != resp1.Results[0].TrackName {
+		t.Errorf("expected cached result %q, got %q", resp1.Results[0].TrackName, resp2.Results[0].TrackName)
+	}
+}
+
+func TestCache_KeyedByRequestURL(t *testing.T) {
+	var callCount int32
+	server := newCountingServer(t, &callCount)
+	c := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: t.TempDir()})
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1}, Country: "gb"}); err != nil {
+		if _, ok := err.(*NotFoundError); !ok {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 API calls for distinct URLs, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestCache_ExpiredEntryIsRefetched(t *testing.T) {
+	var callCount int32
+	server := newCountingServer(t, &callCount)
+	dir := t.TempDir()
+	c := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir, CacheTTL: time.Minute})
+
+	req := SearchRequest{Term: "jack johnson"}
+	if _, err := c.Search(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := filepath.Glob(filepath.Join(dir, "*[0-9a-f]"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected 1 cache entry, got %v (err %v)", entries, err)
+	}
+	stale := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(entries[0], stale, stale); err != nil {
+		t.Fatalf("failed to age cache entry: %v", err)
+	}
+
+	resp, err := c.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 API calls, got %d", atomic.LoadInt32(&callCount))
+	}
+	if resp.Results[0].TrackName != "Call 2" {
+		t.Errorf("expected refreshed result, got %q", resp.Results[0].TrackName)
+	}
+}
+
+func TestCache_BypassRefreshesEntries(t *testing.T) {
+	var callCount int32
+	server := newCountingServer(t, &callCount)
+	dir := t.TempDir()
+	req := SearchRequest{Term: "jack johnson"}
+
+	cached := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir})
+	if _, err := cached.Search(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bypass := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir, CacheBypass: true})
+	resp, err := bypass.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Results[0].TrackName != "Call 2" {
+		t.Errorf("expected bypass to hit the API, got %q", resp.Results[0].TrackName)
+	}
+
+	resp, err = cached.Search(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Results[0].TrackName != "Call 2" {
+		t.Errorf("expected bypass to refresh the cache entry, got %q", resp.Results[0].TrackName)
+	}
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 API calls, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestCache_ConcurrentClientsFetchOnce(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"resultCount":0,"results":[]}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for range 4 {
+		c := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: dir})
+		wg.Go(func() {
+			_, err := c.Search(context.Background(), SearchRequest{Term: "jack johnson"})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if atomic.LoadInt32(&callCount) != 1 {
+		t.Errorf("expected 1 API call, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"resultCount":0,"results":[]}`))
+	}))
+	defer server.Close()
+	c := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: t.TempDir()})
+
+	if _, err := c.Search(context.Background(), SearchRequest{Term: "x"}); err == nil {
+		t.Fatal("expected error on first request")
+	}
+	if _, err := c.Search(context.Background(), SearchRequest{Term: "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 API calls, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestCache_ExpiredEntryIsRemovedOnRead(t *testing.T) {
+	dir := t.TempDir()
+	rc := newResponseCache(dir, time.Minute, false)
+	key := rc.key("https://itunes.apple.com/search?term=x")
+	if err := rc.write(key, []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stale := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, key), stale, stale); err != nil {
+		t.Fatalf("failed to age cache entry: %v", err)
+	}
+
+	if _, ok := rc.read(key); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+	if _, err := os.Stat(filepath.Join(dir, key)); !os.IsNotExist(err) {
+		t.Errorf("expected expired entry to be removed, got %v", err)
+	}
+}
+
+func TestCache_SweepRemovesStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	rc := newResponseCache(dir, time.Minute, false)
+	staleKey := rc.key("stale")
+	freshKey := rc.key("fresh")
+	heldKey := rc.key("held")
+
+	stale := time.Now().Add(-2 * time.Minute)
+	files := map[string]bool{
+		staleKey:                 false,
+		staleKey + ".lock":       false,
+		staleKey + ".123456.tmp": false,
+		freshKey:                 true,
+		heldKey + ".lock":        true,
+		"unrelated.txt":          true,
+	}
+	for name := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if name != freshKey {
+			if err := os.Chtimes(path, stale, stale); err != nil {
+				t.Fatalf("failed to age %s: %v", name, err)
+			}
+		}
+	}
+
+	lock, err := acquireFileLock(context.Background(), filepath.Join(dir, heldKey+".lock"))
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	defer lock.release()
+
+	newResponseCache(dir, time.Minute, false)
+
+	for name, kept := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != kept {
+			t.Errorf("%s: expected kept %t, got exists %t", name, kept, exists)
+		}
+	}
+}
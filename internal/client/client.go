@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
@@ -19,23 +20,149 @@ import (
 // DefaultBaseURL is the base URL for the iTunes Search API.
 const DefaultBaseURL = "https://itunes.apple.com"
 
+// maxErrorBodySize is the number of response body bytes kept in an APIError.
+const maxErrorBodySize = 512
+
+// DefaultHTTPTimeout is the default timeout applied to each HTTP request.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// Config holds the tunable settings used to construct a Client.
+type Config struct {
+	BaseURL           string
+	Timeout           time.Duration
+	MaxRetries        int
+	MaxRetryWait      time.Duration
+	RateLimitRequests int
+	RateLimitDuration time.Duration
+	// RateLimitBackend selects where the rate limiter's token bucket lives.
+	// The file backend shares one budget, kept in RateLimitFile, between every
+	// provider process on the host. When empty, the in-memory bucket is used.
+	RateLimitBackend string
+	RateLimitFile    string
+	// CacheDir enables the on-disk response cache when non-empty.
+	CacheDir    string
+	CacheTTL    time.Duration
+	CacheBypass bool
+	// FixtureMode enables recording or replaying HTTP fixtures in FixtureDir.
+	FixtureMode string
+	FixtureDir  string
+	// LookupBatchWindow enables lookup batching when positive: lookups by a
+	// single ID, bundle ID, or AMG artist ID selector that arrive within the
+	// window are merged into one request.
+	LookupBatchWindow time.Duration
+	// DefaultCountry is the storefront queried when a request or URL does not
+	// name one. When empty, the API default (us) applies.
+	DefaultCountry string
+}
+
+// DefaultConfig returns a Config populated with the provider defaults.
+func DefaultConfig() Config {
+	return Config{
+		BaseURL:           DefaultBaseURL,
+		Timeout:           DefaultHTTPTimeout,
+		MaxRetries:        common.MaxRetries,
+		MaxRetryWait:      common.MaxRetryWait,
+		RateLimitRequests: common.RateLimitRequests,
+		RateLimitDuration: common.RateLimitDuration,
+		CacheTTL:          common.DefaultCacheTTL,
+	}
+}
+
 // Client represents the iTunes Search API client.
 type Client struct {
-	apiClient   *http.Client
-	logger      Logger
-	rateLimiter *tokenBucket
-	baseURL     string
+	apiClient      *http.Client
+	logger         Logger
+	hooks          Hooks
+	rateLimiter    rateLimiter
+	cache          *responseCache
+	flights        flightGroup
+	batcher        *lookupBatcher
+	offline        bool
+	baseURL        string
+	maxRetries     int
+	maxRetryWait   time.Duration
+	defaultCountry string
+	// jitter randomises backoff waits; it is replaced in tests.
+	jitter func(time.Duration) time.Duration
 }
 
-// NewClient creates a new iTunes Search API client instance.
+// NewClient creates a new iTunes Search API client instance using the default configuration.
 func NewClient() *Client {
-	return &Client{
-		apiClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		rateLimiter: newTokenBucket(),
-		baseURL:     DefaultBaseURL,
+	return NewClientWithConfig(DefaultConfig())
+}
+
+// NewClientWithConfig creates a new iTunes Search API client instance from the
+// provided configuration. Zero values fall back to their defaults.
+func NewClientWithConfig(cfg Config) *Client {
+	defaults := DefaultConfig()
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaults.BaseURL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaults.MaxRetries
+	}
+	if cfg.MaxRetryWait <= 0 {
+		cfg.MaxRetryWait = defaults.MaxRetryWait
+	}
+	if cfg.RateLimitRequests <= 0 {
+		cfg.RateLimitRequests = defaults.RateLimitRequests
+	}
+	if cfg.RateLimitDuration <= 0 {
+		cfg.RateLimitDuration = defaults.RateLimitDuration
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaults.CacheTTL
+	}
+
+	var cache *responseCache
+	if cfg.CacheDir != "" {
+		cache = newResponseCache(cfg.CacheDir, cfg.CacheTTL, cfg.CacheBypass)
+	}
+
+	httpClient := &http.Client{
+		Timeout: cfg.Timeout,
+	}
+	if cfg.FixtureMode != "" {
+		httpClient.Transport = newFixtureTransport(cfg.FixtureMode, cfg.FixtureDir, http.DefaultTransport)
+	}
+
+	c := &Client{
+		apiClient:      httpClient,
+		rateLimiter:    newRateLimiter(cfg),
+		cache:          cache,
+		offline:        cfg.FixtureMode == FixtureModeReplay,
+		baseURL:        strings.TrimSuffix(cfg.BaseURL, "/"),
+		maxRetries:     cfg.MaxRetries,
+		maxRetryWait:   cfg.MaxRetryWait,
+		defaultCountry: strings.ToLower(cfg.DefaultCountry),
+		jitter:         fullJitter,
+	}
+	if cfg.LookupBatchWindow > 0 {
+		c.batcher = newLookupBatcher(c, cfg.LookupBatchWindow)
+	}
+	return c
+}
+
+// DefaultCountry returns the storefront queried when a request does not name
+// one: the configured default country, or the API default.
+func (c *Client) DefaultCountry() string {
+	if c.defaultCountry != "" {
+		return c.defaultCountry
+	}
+	return common.DefaultCountry
+}
+
+// country returns the storefront to send for a request, falling back to the
+// configured default country. It is empty when neither is set, leaving the
+// choice to the API.
+func (c *Client) country(requested string) string {
+	if requested != "" {
+		return requested
 	}
+	return c.defaultCountry
 }
 
 // SetLogger sets the logger for the client.
@@ -48,11 +175,99 @@ func (c *Client) setBaseURL(baseURL string) {
 	c.baseURL = baseURL
 }
 
+// getBody returns the raw response body for the API URL. Concurrent requests
+// for the same URL, including its JSONP callback, share a single round trip.
+// The returned body may be shared and must not be modified.
+func (c *Client) getBody(ctx context.Context, operation, apiURL string) ([]byte, error) {
+	start := time.Now()
+	body, shared, err := c.flights.do(ctx, apiURL, func(ctx context.Context) ([]byte, error) {
+		return c.observe(ctx, operation, apiURL, func(ctx context.Context) ([]byte, error) {
+			return c.loadBody(ctx, apiURL)
+		})
+	})
+	if shared {
+		if c.logger != nil {
+			c.logger.LogEvent(ctx, "Shared response of identical in-flight request", map[string]any{
+				"url": apiURL,
+			})
+		}
+		if c.hooks != nil {
+			c.hooks.RequestCompleted(ctx, RequestMetrics{
+				Operation: operation,
+				URL:       apiURL,
+				Latency:   time.Since(start),
+				Shared:    true,
+				Bytes:     len(body),
+				Err:       err,
+			})
+		}
+	}
+	return body, err
+}
+
+// loadBody returns the raw response body for the API URL, serving it from the
+// on-disk cache when one is configured and holds a fresh entry.
+func (c *Client) loadBody(ctx context.Context, apiURL string) ([]byte, error) {
+	if c.cache == nil {
+		return c.fetchBody(ctx, apiURL)
+	}
+
+	body, hit, err := c.cache.get(ctx, apiURL, func(ctx context.Context) ([]byte, error) {
+		return c.fetchBody(ctx, apiURL)
+	}, func(err error) {
+		if c.logger != nil {
+			c.logger.LogEvent(ctx, "Failed to write response cache entry", map[string]any{
+				"url":   apiURL,
+				"error": err.Error(),
+			})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if hit {
+		if m := requestMetricsFrom(ctx); m != nil {
+			m.CacheHit = true
+		}
+		if c.logger != nil {
+			c.logger.LogEvent(ctx, "Serving response from cache", map[string]any{
+				"url": apiURL,
+			})
+		}
+	}
+	return body, nil
+}
+
+// fetchBody performs the request and reads the full response body.
+func (c *Client) fetchBody(ctx context.Context, apiURL string) ([]byte, error) {
+	resp, err := c.doRequest(ctx, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("warning: failed to close response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	return body, nil
+}
+
 // doRequest performs a rate-limited HTTP GET request to the specified URL,
-// retrying on HTTP 429 and 5xx responses up to MaxRetries times.
+// retrying on HTTP 429 and 5xx responses up to the configured maximum number of times.
+// Replayed requests never reach the API, so they skip the rate limiter.
 func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, error) {
-	if err := c.rateLimiter.take(ctx); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
+	var limiter rateLimiter
+	if !c.offline {
+		limiter = c.rateLimiter
+	}
+	if err := c.waitForToken(ctx, limiter); err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -62,20 +277,94 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 
 	req.Header.Add("Accept", "application/json")
 
+	return c.doWithRetry(ctx, req, true, limiter)
+}
+
+// waitForToken takes a token from limiter, if there is one, adding the time
+// spent waiting to the request's metrics.
+func (c *Client) waitForToken(ctx context.Context, limiter rateLimiter) error {
+	if limiter == nil {
+		return nil
+	}
+
+	start := time.Now()
+	err := limiter.take(ctx)
+	if m := requestMetricsFrom(ctx); m != nil {
+		m.RateLimitWait += time.Since(start)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return canceledError(ctx)
+		}
+		return fmt.Errorf("rate limiter error: %w", err)
+	}
+	return nil
+}
+
+// doWithRetry sends the request, retrying on HTTP 429 and 5xx responses and
+// transient network errors up to the configured maximum number of times.
+// Retries wait for the time requested by Retry-After, or otherwise back off
+// exponentially with full jitter. Successful response bodies are only passed
+// to the logger when logBody is true. When limiter is non-nil, each retry
+// takes a token from it like a new request, and rate-limited and successful
+// responses adjust its rate.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request, logBody bool, limiter rateLimiter) (*http.Response, error) {
 	if c.logger != nil {
 		c.logger.LogRequest(ctx, req.Method, req.URL.String(), nil)
 	}
 
+	m := requestMetricsFrom(ctx)
 	retryCount := 0
 	for {
+		sent := time.Now()
 		resp, err := c.apiClient.Do(req)
+		if m != nil {
+			m.Attempts++
+			if resp != nil {
+				m.StatusCode = resp.StatusCode
+			}
+		}
 		if err != nil {
-			return nil, fmt.Errorf("error making request: %w", err)
+			if ctx.Err() != nil {
+				return nil, canceledError(ctx)
+			}
+			if !isTransientNetworkError(err) {
+				return nil, fmt.Errorf("error making request: %w", err)
+			}
+
+			retryCount++
+			if retryCount >= c.maxRetries {
+				return nil, fmt.Errorf("error making request after %d attempts: %w", retryCount, err)
+			}
+
+			waitDuration := c.backoff(retryCount)
+			if c.logger != nil {
+				c.logger.LogEvent(ctx, "Network error, retrying with backoff", map[string]any{
+					"error":         err.Error(),
+					"wait_duration": waitDuration.String(),
+					"retry_count":   retryCount,
+				})
+			}
+			if err := sleepContext(ctx, waitDuration); err != nil {
+				return nil, err
+			}
+			if err := c.waitForToken(ctx, limiter); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		switch {
 		case resp.StatusCode == http.StatusOK:
-			if c.logger != nil && resp.Body != nil {
+			if limiter != nil {
+				c.adaptRate(ctx, "Rate limit recovering after success", func() (float64, bool, error) {
+					return limiter.recover(ctx)
+				})
+			}
+			if c.logger != nil && !logBody {
+				c.logger.LogResponse(ctx, resp.StatusCode, resp.Header, nil)
+			}
+			if c.logger != nil && logBody && resp.Body != nil {
 				responseBody, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
@@ -86,70 +375,45 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 			}
 			return resp, nil
 
-		case resp.StatusCode == http.StatusTooManyRequests:
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
 			if c.logger != nil {
 				c.logger.LogResponse(ctx, resp.StatusCode, resp.Header, nil)
 			}
 
-			retryAfter := resp.Header.Get("Retry-After")
-			_ = resp.Body.Close()
-
-			retryCount++
-			if retryCount >= common.MaxRetries {
-				return nil, fmt.Errorf("exceeded maximum retries (%d) for rate-limited requests", common.MaxRetries)
-			}
-
-			if retryAfter != "" {
-				seconds, err := time.ParseDuration(retryAfter + "s")
-				if err == nil {
-					waitDuration := min(seconds+(1*time.Second), common.MaxRetryWait)
-					if c.logger != nil {
-						c.logger.LogAuth(ctx, "Rate limited, waiting before retry", map[string]any{
-							"retry_after_seconds": retryAfter,
-							"wait_duration":       waitDuration.String(),
-							"retry_count":         retryCount,
-						})
-					}
-					select {
-					case <-ctx.Done():
-						return nil, ctx.Err()
-					case <-time.After(waitDuration):
-					}
-					continue
-				}
-				if c.logger != nil {
-					c.logger.LogAuth(ctx, "Failed to parse Retry-After header", map[string]any{
-						"retry_after": retryAfter,
-						"error":       err.Error(),
-					})
-				}
+			var sentinel error
+			if resp.StatusCode == http.StatusTooManyRequests {
+				sentinel = ErrRateLimited
 			}
 
-			return nil, fmt.Errorf("received 429 Too Many Requests with no valid Retry-After header")
-
-		case resp.StatusCode >= 500:
-			if c.logger != nil {
-				c.logger.LogResponse(ctx, resp.StatusCode, resp.Header, nil)
+			retryCount++
+			if retryCount >= c.maxRetries {
+				return nil, newAPIError(req, resp, retryCount, sentinel)
 			}
 			_ = resp.Body.Close()
 
-			retryCount++
-			if retryCount >= common.MaxRetries {
-				return nil, fmt.Errorf("exceeded maximum retries (%d) for server error (status %d)", common.MaxRetries, resp.StatusCode)
+			// A 429, or any response asking the client to wait, slows every
+			// request sharing the limiter rather than just this one.
+			waitDuration, source := c.retryDelay(resp, retryCount)
+			_, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if limiter != nil && (resp.StatusCode == http.StatusTooManyRequests || hasRetryAfter) {
+				c.adaptRate(ctx, fmt.Sprintf("Rate limit lowered after HTTP %d", resp.StatusCode), func() (float64, bool, error) {
+					return limiter.backOff(ctx, sent, waitDuration)
+				})
 			}
-
-			waitDuration := min(common.RetryBaseDelay*time.Duration(1<<uint(retryCount-1)), common.MaxRetryWait)
 			if c.logger != nil {
-				c.logger.LogAuth(ctx, "Server error, retrying with backoff", map[string]any{
+				c.logger.LogEvent(ctx, "Request failed, waiting before retry", map[string]any{
 					"status_code":   resp.StatusCode,
+					"retry_after":   resp.Header.Get("Retry-After"),
+					"wait_source":   source,
 					"wait_duration": waitDuration.String(),
 					"retry_count":   retryCount,
 				})
 			}
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(waitDuration):
+			if err := sleepContext(ctx, waitDuration); err != nil {
+				return nil, err
+			}
+			if err := c.waitForToken(ctx, limiter); err != nil {
+				return nil, err
 			}
 			continue
 
@@ -157,18 +421,74 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 			if c.logger != nil {
 				c.logger.LogResponse(ctx, resp.StatusCode, resp.Header, nil)
 			}
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+			return nil, newAPIError(req, resp, retryCount+1, nil)
 		}
 	}
 }
 
+// adaptRate applies feedback to the rate limiter and logs the resulting rate
+// when it changes. Feedback is best effort: a failure to record it only leaves
+// the rate as is.
+func (c *Client) adaptRate(ctx context.Context, message string, feedback func() (float64, bool, error)) {
+	rate, changed, err := feedback()
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		c.logger.LogEvent(ctx, "Failed to update rate limit", map[string]any{
+			"error": err.Error(),
+		})
+		return
+	}
+	if !changed {
+		return
+	}
+	c.logger.LogEvent(ctx, message, map[string]any{
+		"requests_per_minute": rate * 60,
+	})
+}
+
+// sleepContext waits for d, returning a canceled error if ctx ends first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return canceledError(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newAPIError builds an APIError from the final response of a request, which
+// it closes after reading the start of the body.
+func newAPIError(req *http.Request, resp *http.Response, attempts int, sentinel error) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		URL:        req.URL.String(),
+		Attempts:   attempts,
+		Err:        sentinel,
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+
+	if resp.Body != nil {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		apiErr.Body = strings.TrimSpace(string(snippet))
+		_ = resp.Body.Close()
+	}
+
+	return apiErr
+}
+
 // addCommonParameters adds shared query parameters to the URL query values.
 func (c *Client) addCommonParameters(query url.Values, entity, country string, limit int64) {
 	if entity != "" {
 		query.Set("entity", entity)
 	}
-	if country != "" {
+	if country := c.country(country); country != "" {
 		query.Set("country", country)
 	}
 	if limit > 0 {
@@ -8,10 +8,23 @@ package client
 import (
 	"context"
 	"testing"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/acctest"
 )
 
+// newAccClient returns a client for acceptance tests. Setting ITUNES_FIXTURE_MODE
+// records or replays responses, by default in the repository's testdata/fixtures,
+// so the tests can run without reaching Apple.
+func newAccClient(t *testing.T) *Client {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.FixtureMode, cfg.FixtureDir = acctest.Fixtures(t)
+	return NewClientWithConfig(cfg)
+}
+
 func TestAccLookup_ByBundleID(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Lookup(context.Background(), LookupRequest{
 		BundleIDs: []string{"com.apple.Pages"},
 		Country:   "us",
@@ -28,7 +41,7 @@ func TestAccLookup_ByBundleID(t *testing.T) {
 }
 
 func TestAccLookup_ByMultipleBundleIDs(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Lookup(context.Background(), LookupRequest{
 		BundleIDs: []string{"com.apple.Pages", "com.apple.Keynote"},
 		Country:   "us",
@@ -59,7 +72,7 @@ func TestAccLookup_ByMultipleBundleIDs(t *testing.T) {
 }
 
 func TestAccLookup_ByID(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 
 	pagesResult, err := c.Lookup(context.Background(), LookupRequest{
 		BundleIDs: []string{"com.apple.Pages"},
@@ -89,7 +102,7 @@ func TestAccLookup_ByID(t *testing.T) {
 }
 
 func TestAccLookup_NotFoundID(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	_, err := c.Lookup(context.Background(), LookupRequest{
 		IDs:     []int64{9999999999},
 		Country: "us",
@@ -103,7 +116,7 @@ func TestAccLookup_NotFoundID(t *testing.T) {
 }
 
 func TestAccLookup_WithCountry(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Lookup(context.Background(), LookupRequest{
 		BundleIDs: []string{"com.apple.Pages"},
 		Country:   "gb",
@@ -117,7 +130,7 @@ func TestAccLookup_WithCountry(t *testing.T) {
 }
 
 func TestAccLookup_WithEntityFilter(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Lookup(context.Background(), LookupRequest{
 		BundleIDs: []string{"com.apple.Pages"},
 		Country:   "us",
@@ -132,7 +145,7 @@ func TestAccLookup_WithEntityFilter(t *testing.T) {
 }
 
 func TestAccSearch_Basic(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Search(context.Background(), SearchRequest{
 		Term:    "Pages",
 		Media:   "software",
@@ -151,7 +164,7 @@ func TestAccSearch_Basic(t *testing.T) {
 }
 
 func TestAccSearch_WithLimit(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Search(context.Background(), SearchRequest{
 		Term:    "Apple",
 		Media:   "software",
@@ -167,7 +180,7 @@ func TestAccSearch_WithLimit(t *testing.T) {
 }
 
 func TestAccSearch_DifferentMedia(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Search(context.Background(), SearchRequest{
 		Term:    "Beatles",
 		Media:   "music",
@@ -183,7 +196,7 @@ func TestAccSearch_DifferentMedia(t *testing.T) {
 }
 
 func TestAccSearch_ResultFields(t *testing.T) {
-	c := NewClient()
+	c := newAccClient(t)
 	result, err := c.Search(context.Background(), SearchRequest{
 		Term:    "Pages",
 		Media:   "software",
@@ -5,17 +5,25 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
 )
 
+// newTestClient returns a client for serverURL whose rate limiter, unlike the
+// default, does not hold retries after a 429 for seconds.
 func newTestClient(serverURL string) *Client {
 	c := NewClient()
 	c.setBaseURL(serverURL)
+	c.rateLimiter = newTokenBucket(1000, time.Second)
 	return c
 }
 
@@ -45,6 +53,7 @@ func TestDoRequest_NonRetryableError(t *testing.T) {
 	defer server.Close()
 
 	c := newTestClient(server.URL)
+	c.jitter = func(time.Duration) time.Duration { return 0 }
 	_, err := c.doRequest(context.Background(), server.URL+"/test")
 	if err == nil {
 		t.Fatal("expected error for 500 status")
@@ -89,6 +98,16 @@ func TestDoRequest_429ExceedsMaxRetries(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error after exceeding max retries")
 	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Attempts != common.MaxRetries || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("unexpected API error: %+v", apiErr)
+	}
 }
 
 func TestDoRequest_429NoRetryAfterHeader(t *testing.T) {
@@ -98,10 +117,14 @@ func TestDoRequest_429NoRetryAfterHeader(t *testing.T) {
 	defer server.Close()
 
 	c := newTestClient(server.URL)
+	c.jitter = func(time.Duration) time.Duration { return 0 }
 	_, err := c.doRequest(context.Background(), server.URL+"/test")
 	if err == nil {
 		t.Fatal("expected error for 429 without Retry-After")
 	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
 }
 
 func TestDoRequest_ContextCancellation(t *testing.T) {
@@ -119,4 +142,231 @@ func TestDoRequest_ContextCancellation(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error from context cancellation")
 	}
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ErrCanceled wrapping the deadline, got %v", err)
+	}
+}
+
+func TestDoRequest_APIErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, `{"errorMessage":"Invalid value(s) for key(s): [country]"}`+strings.Repeat(" padding", 200))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.doRequest(context.Background(), server.URL+"/lookup?id=1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Attempts != 1 {
+		t.Errorf("unexpected status or attempts: %+v", apiErr)
+	}
+	if apiErr.URL != server.URL+"/lookup?id=1" {
+		t.Errorf("unexpected URL %q", apiErr.URL)
+	}
+	if apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("expected RetryAfter 7s, got %s", apiErr.RetryAfter)
+	}
+	if !strings.HasPrefix(apiErr.Body, `{"errorMessage"`) || len(apiErr.Body) > maxErrorBodySize {
+		t.Errorf("unexpected body snippet (%d bytes): %q", len(apiErr.Body), apiErr.Body)
+	}
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("did not expect ErrRateLimited for a 400 response")
+	}
+}
+
+func TestNewClientWithConfig_ZeroValuesUseDefaults(t *testing.T) {
+	c := NewClientWithConfig(Config{})
+	if c.baseURL != DefaultBaseURL {
+		t.Errorf("expected base URL %q, got %q", DefaultBaseURL, c.baseURL)
+	}
+	if c.maxRetries != common.MaxRetries {
+		t.Errorf("expected max retries %d, got %d", common.MaxRetries, c.maxRetries)
+	}
+	if c.apiClient.Timeout != DefaultHTTPTimeout {
+		t.Errorf("expected timeout %s, got %s", DefaultHTTPTimeout, c.apiClient.Timeout)
+	}
+}
+
+func TestNewClientWithConfig_MaxRetries(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClientWithConfig(Config{BaseURL: server.URL + "/", MaxRetries: 2, RateLimitRequests: 1000, RateLimitDuration: time.Second})
+	if c.baseURL != server.URL {
+		t.Errorf("expected trailing slash to be trimmed, got %q", c.baseURL)
+	}
+
+	_, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err == nil {
+		t.Fatal("expected error after exceeding max retries")
+	}
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 calls, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestFetchArtwork_DoesNotUseRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "image/*" {
+			t.Errorf("expected image Accept header, got %q", r.Header.Get("Accept"))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "image-bytes")
+	}))
+	defer server.Close()
+
+	c := NewClientWithConfig(Config{BaseURL: server.URL, RateLimitRequests: 1, RateLimitDuration: time.Hour})
+	for range 3 {
+		data, err := c.FetchArtwork(context.Background(), server.URL+"/art.png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "image-bytes" {
+			t.Errorf("expected image bytes, got %q", data)
+		}
+	}
+}
+
+func TestDoRequest_FakeServerRateLimitRecovers(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	server.RateLimit(1, "0")
+	c := newTestClient(server.URL)
+
+	result, err := c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Keynote"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Errorf("expected 1 result, got %d", len(result.Results))
+	}
+	if server.RequestCount() != 2 {
+		t.Errorf("expected 2 requests, got %d", server.RequestCount())
+	}
+}
+
+func TestDoRequest_429LowersRateAndSuccessRecovers(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	tb := c.rateLimiter.(*tokenBucket)
+
+	resp, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if want := tb.baseRate/2 + tb.baseRate*rateIncreaseStep; tb.refillRate != want {
+		t.Errorf("expected rate %v after a 429 and a success, got %v", want, tb.refillRate)
+	}
+}
+
+// countingLimiter is a rateLimiter that never waits and counts the tokens taken.
+type countingLimiter struct {
+	takes    atomic.Int32
+	backOffs atomic.Int32
+}
+
+func (l *countingLimiter) take(ctx context.Context) error {
+	l.takes.Add(1)
+	return nil
+}
+
+func (l *countingLimiter) backOff(ctx context.Context, sent time.Time, pause time.Duration) (float64, bool, error) {
+	l.backOffs.Add(1)
+	return 0, false, nil
+}
+
+func (l *countingLimiter) recover(ctx context.Context) (float64, bool, error) {
+	return 0, false, nil
+}
+
+func TestDoRequest_RetriesTakeRateLimiterTokens(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&callCount, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = fmt.Fprint(w, `{"results":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClientWithConfig(Config{BaseURL: server.URL, MaxRetryWait: time.Millisecond})
+	limiter := &countingLimiter{}
+	c.rateLimiter = limiter
+
+	resp, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := limiter.takes.Load(); got != 3 {
+		t.Errorf("expected the request and both retries to take a token, got %d", got)
+	}
+}
+
+func TestDoRequest_ServerErrorWithRetryAfterBacksOff(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	server.FailWithStatus(1, http.StatusServiceUnavailable)
+	server.InjectFaults(fakeitunes.Fault{StatusCode: http.StatusServiceUnavailable, RetryAfter: "0"})
+
+	c := NewClientWithConfig(Config{BaseURL: server.URL, MaxRetryWait: time.Millisecond})
+	limiter := &countingLimiter{}
+	c.rateLimiter = limiter
+
+	if _, err := c.Search(context.Background(), SearchRequest{Term: "jack johnson"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := limiter.backOffs.Load(); got != 1 {
+		t.Errorf("expected only the response with Retry-After to back off, got %d", got)
+	}
+	if server.RequestCount() != 3 {
+		t.Errorf("expected 3 requests, got %d", server.RequestCount())
+	}
+}
+
+func TestFetchArtwork_DoesNotAdaptRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewClientWithConfig(Config{BaseURL: server.URL, MaxRetries: 2})
+	tb := c.rateLimiter.(*tokenBucket)
+
+	if _, err := c.FetchArtwork(context.Background(), server.URL+"/art.jpg"); err == nil {
+		t.Fatal("expected error for a rate-limited artwork download")
+	}
+	if tb.refillRate != tb.baseRate {
+		t.Errorf("expected artwork 429s to leave the API rate at %v, got %v", tb.baseRate, tb.refillRate)
+	}
 }
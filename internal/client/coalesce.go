@@ -0,0 +1,81 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent fetches of the same key, so identical
+// requests made in parallel share one round trip and one rate limit token.
+// The zero value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a fetch in progress and the callers waiting for its result.
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do returns the result of fetch for key, joining a fetch already in flight
+// for the same key when there is one. shared reports whether the caller
+// joined another caller's fetch. The fetch runs with a context that keeps the
+// first caller's values but is only cancelled once every waiting caller has
+// given up, so one caller timing out does not fail the others. Callers must
+// not modify the returned body, which may be shared.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) (body []byte, shared bool, err error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, shared := g.flights[key]
+	if !shared {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+		go g.run(flightCtx, key, f, fetch)
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			g.forget(key, f)
+		}
+		g.mu.Unlock()
+		return nil, shared, canceledError(ctx)
+	}
+}
+
+// run performs the fetch and publishes its result to the waiting callers.
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fetch func(context.Context) ([]byte, error)) {
+	defer f.cancel()
+	f.body, f.err = fetch(ctx)
+
+	g.mu.Lock()
+	g.forget(key, f)
+	g.mu.Unlock()
+	close(f.done)
+}
+
+// forget removes f from the group so later callers start a new fetch. Callers
+// must hold g.mu.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n callers are waiting on flights in g.
+func waitForWaiters(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		waiting := 0
+		for _, f := range g.flights {
+			waiting += f.waiters
+		}
+		g.mu.Unlock()
+		if waiting >= n {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers", n)
+}
+
+func TestFlightGroup_SharesConcurrentCalls(t *testing.T) {
+	var g flightGroup
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("body"), nil
+	}
+
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int32
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, shared, err := g.do(context.Background(), "key", fetch)
+			if err != nil || string(body) != "body" {
+				t.Errorf("unexpected result %q, %v", body, err)
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+		}()
+	}
+	waitForWaiters(t, &g, 5)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 fetch, got %d", calls.Load())
+	}
+	if sharedCount.Load() != 4 {
+		t.Errorf("expected 4 callers to share the fetch, got %d", sharedCount.Load())
+	}
+
+	if _, shared, _ := g.do(context.Background(), "key", func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		return nil, nil
+	}); shared || calls.Load() != 2 {
+		t.Error("expected a later call to start a new fetch")
+	}
+}
+
+func TestFlightGroup_CanceledWaiterDoesNotCancelOthers(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]byte, error) {
+		select {
+		case <-release:
+			return []byte("body"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(context.Background(), "key", fetch)
+		result <- err
+	}()
+	waitForWaiters(t, &g, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := g.do(ctx, "key", fetch); !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected ErrCanceled for the canceled caller, got %v", err)
+	}
+
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("expected the remaining caller to succeed, got %v", err)
+	}
+}
+
+func TestFlightGroup_AllWaitersCanceledCancelsFetch(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	fetchCanceled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, _, err := g.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		close(fetchCanceled)
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected ErrCanceled, got %v", err)
+	}
+
+	select {
+	case <-fetchCanceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the fetch to be canceled once no caller was waiting")
+	}
+}
+
+func TestClient_CoalescesIdenticalRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		callback string
+		response string
+	}{
+		{name: "json", response: `{"resultCount":1,"results":[{"trackId":1}]}`},
+		{name: "jsonp", callback: "cb", response: `cb({"resultCount":1,"results":[{"trackId":1}]});`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				<-release
+				_, _ = fmt.Fprint(w, tt.response)
+			}))
+			defer server.Close()
+
+			c := NewClientWithConfig(Config{BaseURL: server.URL, RateLimitRequests: 10, RateLimitDuration: time.Hour})
+			tb := c.rateLimiter.(*tokenBucket)
+
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					result, err := c.Search(context.Background(), SearchRequest{Term: "keynote", Callback: tt.callback})
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+						return
+					}
+					if len(result.Results) != 1 || result.Results[0].TrackID != 1 {
+						t.Errorf("unexpected results %+v", result.Results)
+					}
+				}()
+			}
+			waitForWaiters(t, &c.flights, 4)
+			close(release)
+			wg.Wait()
+
+			if requests.Load() != 1 {
+				t.Errorf("expected 1 request, got %d", requests.Load())
+			}
+			if tokens := tb.tokens; tokens < 8.9 || tokens > 9.1 {
+				t.Errorf("expected one token to be spent, %v remain", tokens)
+			}
+		})
+	}
+}
+
+func TestClient_DoesNotCoalesceDifferentRequests(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		if cb := r.URL.Query().Get("callback"); cb != "" {
+			_, _ = fmt.Fprintf(w, `%s({"results":[]});`, cb)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	var wg sync.WaitGroup
+	for _, callback := range []string{"", "first", "second"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Search(context.Background(), SearchRequest{Term: "keynote", Callback: callback}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	waitForWaiters(t, &c.flights, 3)
+	close(release)
+	wg.Wait()
+
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", requests.Load())
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileLockPollInterval is how often a blocked caller retries an exclusive file lock.
+const fileLockPollInterval = 25 * time.Millisecond
+
+// fileLock is an exclusive advisory lock held on a file, shared between processes.
+type fileLock struct {
+	f *os.File
+}
+
+// acquireFileLock opens (creating if needed) the file at path and blocks until
+// an exclusive lock on it is held or the context is cancelled.
+func acquireFileLock(ctx context.Context, path string) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening lock file: %w", err)
+	}
+
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("error locking %s: %w", path, err)
+		}
+		if locked {
+			return &fileLock{f: f}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, ctx.Err()
+		case <-time.After(fileLockPollInterval):
+		}
+	}
+}
+
+// release unlocks and closes the lock file.
+func (l *fileLock) release() {
+	_ = unlockFile(l.f)
+	_ = l.f.Close()
+}
@@ -0,0 +1,186 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// Fixture modes select how the client's HTTP transport uses recorded fixtures.
+const (
+	// FixtureModeRecord performs real requests and saves each final response
+	// to the fixture directory.
+	FixtureModeRecord = "record"
+	// FixtureModeReplay serves responses from the fixture directory without touching the network.
+	FixtureModeReplay = "replay"
+)
+
+// FixtureModes lists the supported fixture modes.
+var FixtureModes = []string{FixtureModeRecord, FixtureModeReplay}
+
+// ErrFixtureNotFound is returned in replay mode when no fixture was recorded for a request.
+var ErrFixtureNotFound = errors.New("no recorded fixture for request")
+
+// fixture is the on-disk representation of a single recorded response. Text
+// bodies are stored verbatim so fixtures stay reviewable; binary bodies such as
+// artwork are base64 encoded.
+type fixture struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// fixtureTransport is an http.RoundTripper that records responses to, or
+// replays them from, a directory of fixture files keyed by method and URL.
+type fixtureTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+}
+
+// newFixtureTransport returns a transport for the given mode. Requests are
+// forwarded to next when recording.
+func newFixtureTransport(mode, dir string, next http.RoundTripper) *fixtureTransport {
+	return &fixtureTransport{
+		mode: mode,
+		dir:  dir,
+		next: next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(t.dir, fixtureFileName(req.Method, req.URL.String()))
+
+	if t.mode == FixtureModeReplay {
+		return t.replay(req, path)
+	}
+	return t.record(req, path)
+}
+
+// replay builds a response from the fixture stored at path.
+func (t *fixtureTransport) replay(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrFixtureNotFound, req.Method, req.URL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture: %w", err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("error decoding fixture %s: %w", path, err)
+	}
+
+	body := []byte(f.Body)
+	if f.BodyBase64 != "" {
+		body, err = base64.StdEncoding.DecodeString(f.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding fixture body %s: %w", path, err)
+		}
+	}
+
+	header := f.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record forwards the request and saves the response at path before returning
+// it. Only successful and 404 responses are saved: rate-limit and server error
+// responses are retried by the client, and replaying them would make every
+// replay fail or back off.
+func (t *fixtureTransport) record(req *http.Request, path string) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if !recordable(resp.StatusCode) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response for fixture: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	f := fixture{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     fixtureHeader(resp.Header),
+	}
+	if utf8.Valid(body) {
+		f.Body = string(body)
+	} else {
+		f.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding fixture: %w", err)
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("error writing fixture: %w", err)
+	}
+
+	return resp, nil
+}
+
+// recordable reports whether a response with the given status code is a final
+// answer worth saving as a fixture.
+func recordable(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300 || statusCode == http.StatusNotFound
+}
+
+// fixtureHeader keeps only the response headers the client acts on, so
+// fixtures do not churn on volatile values such as dates and request IDs.
+func fixtureHeader(h http.Header) http.Header {
+	kept := http.Header{}
+	for _, name := range []string{"Content-Type", "Retry-After"} {
+		if v := h.Values(name); len(v) > 0 {
+			kept[name] = v
+		}
+	}
+	return kept
+}
+
+// fixtureFileName returns the fixture file name for a request.
+func fixtureFileName(method, rawURL string) string {
+	sum := sha256.Sum256([]byte(method + " " + rawURL))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
@@ -0,0 +1,155 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
+
+func TestFixtures_RecordThenReplayOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"resultCount":1,"results":[{"trackId":361309726,"bundleId":"com.apple.Pages"}]}`))
+	}))
+	dir := t.TempDir()
+	req := LookupRequest{BundleIDs: []string{"com.apple.Pages"}, Country: "us"}
+
+	recorder := NewClientWithConfig(Config{BaseURL: server.URL, FixtureMode: FixtureModeRecord, FixtureDir: dir})
+	if _, err := recorder.Lookup(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error recording: %v", err)
+	}
+	server.Close()
+
+	replayer := NewClientWithConfig(Config{BaseURL: server.URL, FixtureMode: FixtureModeReplay, FixtureDir: dir})
+	result, err := replayer.Lookup(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if len(result.Results) != 1 || result.Results[0].BundleID != "com.apple.Pages" {
+		t.Errorf("unexpected replayed results: %+v", result.Results)
+	}
+}
+
+func TestFixtures_RecordSkipsRetryableResponses(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	server.RateLimit(1, "0")
+	server.FailWithStatus(1, http.StatusServiceUnavailable)
+	dir := t.TempDir()
+
+	recorder := NewClientWithConfig(Config{
+		BaseURL:           server.URL,
+		FixtureMode:       FixtureModeRecord,
+		FixtureDir:        dir,
+		MaxRetries:        3,
+		MaxRetryWait:      time.Millisecond,
+		RateLimitRequests: 1000,
+		RateLimitDuration: time.Second,
+	})
+	if _, err := recorder.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Pages"}}); err != nil {
+		t.Fatalf("unexpected error recording: %v", err)
+	}
+	if _, err := recorder.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.example.missing"}}); err == nil {
+		t.Fatal("expected a not found error")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading fixtures: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected only the 2 final responses to be recorded, got %d fixtures", len(entries))
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("unexpected error reading fixture: %v", err)
+		}
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatalf("unexpected error decoding fixture: %v", err)
+		}
+		if f.StatusCode != http.StatusOK {
+			t.Errorf("expected only 200 responses to be recorded, got %d", f.StatusCode)
+		}
+	}
+}
+
+func TestFixtures_ReplayMissingFixture(t *testing.T) {
+	c := NewClientWithConfig(Config{BaseURL: "http://127.0.0.1:0", FixtureMode: FixtureModeReplay, FixtureDir: t.TempDir()})
+
+	_, err := c.Search(context.Background(), SearchRequest{Term: "jack johnson"})
+	if !errors.Is(err, ErrFixtureNotFound) {
+		t.Fatalf("expected ErrFixtureNotFound, got %v", err)
+	}
+}
+
+func TestFixtures_BinaryArtworkRoundTrip(t *testing.T) {
+	image := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff, 0xfe}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(image)
+	}))
+	dir := t.TempDir()
+	imageURL := server.URL + "/image/thumb/512x512bb.png"
+
+	recorder := NewClientWithConfig(Config{FixtureMode: FixtureModeRecord, FixtureDir: dir})
+	if _, err := recorder.FetchArtwork(context.Background(), imageURL); err != nil {
+		t.Fatalf("unexpected error recording: %v", err)
+	}
+	server.Close()
+
+	replayer := NewClientWithConfig(Config{FixtureMode: FixtureModeReplay, FixtureDir: dir})
+	data, err := replayer.FetchArtwork(context.Background(), imageURL)
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !bytes.Equal(data, image) {
+		t.Errorf("expected %v, got %v", image, data)
+	}
+}
+
+func TestFixtures_ReplaySkipsRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"resultCount":0,"results":[]}`))
+	}))
+	dir := t.TempDir()
+	req := SearchRequest{Term: "jack johnson"}
+
+	recorder := NewClientWithConfig(Config{BaseURL: server.URL, FixtureMode: FixtureModeRecord, FixtureDir: dir})
+	if _, err := recorder.Search(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error recording: %v", err)
+	}
+	server.Close()
+
+	replayer := NewClientWithConfig(Config{
+		BaseURL:           server.URL,
+		FixtureMode:       FixtureModeReplay,
+		FixtureDir:        dir,
+		RateLimitRequests: 1,
+		RateLimitDuration: time.Hour,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for range 3 {
+		if _, err := replayer.Search(ctx, req); err != nil {
+			t.Fatalf("unexpected error replaying: %v", err)
+		}
+	}
+}
@@ -8,13 +8,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
 )
 
-// Lookup issues a lookup request using the provided selectors and returns the results.
+// Lookup issues a lookup request using the provided selectors and returns the
+// results. When lookup batching is enabled, eligible requests are merged with
+// concurrent ones before being sent.
 func (c *Client) Lookup(ctx context.Context, req LookupRequest) (*ContentResponse, error) {
+	if c.batcher != nil {
+		if key, ok := c.batchKey(req); ok {
+			return c.batcher.lookup(ctx, key, req)
+		}
+	}
+	return c.lookup(ctx, req)
+}
+
+// lookup sends a single lookup request and reports any selector values that
+// returned no results as a NotFoundError. When the limit may have cut rows
+// from the response, missing values are not reported, since an absent row
+// does not show that its value is missing.
+func (c *Client) lookup(ctx context.Context, req LookupRequest) (*ContentResponse, error) {
+	result, err := c.fetchLookup(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if limitMayTruncate(req) {
+		if c.logger != nil {
+			c.logger.LogEvent(ctx, "Skipping missing lookup value detection because the limit may truncate results", map[string]any{
+				"limit":  req.Limit,
+				"entity": req.Entity,
+				"values": lookupSize(req),
+			})
+		}
+		return result, nil
+	}
+
+	notFound, err := c.findMissing(ctx, req, result.Results)
+	if err != nil {
+		return result, err
+	}
+	if !notFound.empty() {
+		return result, notFound
+	}
+
+	return result, nil
+}
+
+// fetchLookup sends a single lookup request and decodes the results.
+func (c *Client) fetchLookup(ctx context.Context, req LookupRequest) (*ContentResponse, error) {
 	query := url.Values{}
 	selectorSet := false
 
@@ -53,6 +98,9 @@ func (c *Client) Lookup(ctx context.Context, req LookupRequest) (*ContentRespons
 
 	limitToUse := min(req.Limit, common.MaxLookupBatchSize)
 
+	if req.Media != "" {
+		query.Set("media", req.Media)
+	}
 	c.addCommonParameters(query, req.Entity, req.Country, limitToUse)
 
 	if req.Sort != "" {
@@ -61,40 +109,238 @@ func (c *Client) Lookup(ctx context.Context, req LookupRequest) (*ContentRespons
 
 	apiURL := fmt.Sprintf("%s/lookup?%s", c.baseURL, query.Encode())
 
-	resp, err := c.doRequest(ctx, apiURL)
+	body, err := c.getBody(ctx, OperationLookup, apiURL)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			fmt.Printf("warning: failed to close response body: %v\n", err)
-		}
-	}()
 
 	var result ContentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("error decoding API response: %w", err)
 	}
+	result.tagStorefront(c.country(req.Country))
 
-	if len(req.IDs) == 0 {
-		return &result, nil
+	return &result, nil
+}
+
+// limitMayTruncate reports whether the request's limit could cut rows from the
+// response: it is below the number of selector values, or an entity expands
+// each value into several rows.
+func limitMayTruncate(req LookupRequest) bool {
+	if req.Limit <= 0 {
+		return false
 	}
+	limit := min(req.Limit, common.MaxLookupBatchSize)
+	return req.Entity != "" || limit < int64(lookupSize(req))
+}
+
+// findMissing determines which requested selector values have no matching
+// result. IDs, bundle IDs, and AMG artist IDs are matched against fields echoed
+// in the results. UPCs, ISBNs, and AMG album and video IDs are matched against
+// rows that carry them; the rest are resolved by probeMissing, which together
+// sends at most common.MaxMissingProbes extra requests per lookup.
+func (c *Client) findMissing(ctx context.Context, req LookupRequest, results []ContentResult) (*NotFoundError, error) {
+	notFound := &NotFoundError{}
 
-	foundIDs := make(map[int64]bool)
-	for _, item := range result.Results {
-		foundIDs[item.TrackID] = true
+	notFound.MissingIDs = missingValues(req.IDs, results, matchesID)
+	notFound.MissingBundleIDs = missingValues(req.BundleIDs, results, matchesBundleID)
+	notFound.MissingAMGIDs = missingValues(req.AMGArtistIDs, results, matchesAMGArtistID)
+
+	budget := common.MaxMissingProbes
+	var err error
+	if notFound.MissingUPCs, err = probeMissing(ctx, c, req, req.UPCs, results, &budget, missingProbe[string]{
+		isPrimary: isCollectionRow,
+		echoes:    func(r ContentResult, v string) bool { return r.UPC == v },
+		set:       func(r *LookupRequest, v []string) { r.UPCs = v },
+	}); err != nil {
+		return nil, err
 	}
+	if notFound.MissingISBNs, err = probeMissing(ctx, c, req, req.ISBNs, results, &budget, missingProbe[string]{
+		isPrimary: isEbookRow,
+		echoes:    func(r ContentResult, v string) bool { return r.ISBN == v },
+		set:       func(r *LookupRequest, v []string) { r.ISBNs = v },
+	}); err != nil {
+		return nil, err
+	}
+	missingAlbums, err := probeMissing(ctx, c, req, req.AMGAlbumIDs, results, &budget, missingProbe[int64]{
+		isPrimary: isCollectionRow,
+		echoes:    func(r ContentResult, v int64) bool { return r.AMGAlbumID == v },
+		set:       func(r *LookupRequest, v []int64) { r.AMGAlbumIDs = v },
+	})
+	if err != nil {
+		return nil, err
+	}
+	missingVideos, err := probeMissing(ctx, c, req, req.AMGVideoIDs, results, &budget, missingProbe[int64]{
+		isPrimary: isMusicVideoRow,
+		echoes:    func(r ContentResult, v int64) bool { return r.AMGVideoID == v },
+		set:       func(r *LookupRequest, v []int64) { r.AMGVideoIDs = v },
+	})
+	if err != nil {
+		return nil, err
+	}
+	notFound.MissingAMGIDs = append(notFound.MissingAMGIDs, append(missingAlbums, missingVideos...)...)
+
+	return notFound, nil
+}
+
+// matchesID reports whether the row is, or belongs to, the iTunes ID.
+func matchesID(r ContentResult, id int64) bool {
+	return r.TrackID == id || r.CollectionID == id || r.ArtistID == id
+}
 
-	var missingIDs []int64
-	for _, id := range req.IDs {
-		if !foundIDs[id] {
-			missingIDs = append(missingIDs, id)
+// matchesBundleID reports whether the row is the app with the bundle ID.
+func matchesBundleID(r ContentResult, bundleID string) bool {
+	return strings.EqualFold(r.BundleID, bundleID)
+}
+
+// matchesAMGArtistID reports whether the row belongs to the AMG artist ID.
+func matchesAMGArtistID(r ContentResult, id int64) bool {
+	return r.AMGArtistID == id
+}
+
+// missingValues returns the requested values for which no result matches.
+func missingValues[T comparable](values []T, results []ContentResult, matches func(ContentResult, T) bool) []T {
+	var missing []T
+	for _, v := range values {
+		found := false
+		for _, r := range results {
+			if matches(r, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, v)
 		}
 	}
+	return missing
+}
+
+// missingProbe describes how to attribute lookup rows to the values of a
+// selector that the API does not always echo in its results.
+type missingProbe[T comparable] struct {
+	// isPrimary reports whether a row is one the selector looks up, as opposed
+	// to a row added by entity expansion.
+	isPrimary func(ContentResult) bool
+	// echoes reports whether a row carries the value it was looked up by.
+	echoes func(ContentResult, T) bool
+	// set sets the selector values on a probe request.
+	set func(*LookupRequest, []T)
+}
 
-	if len(missingIDs) > 0 {
-		return &result, &NotFoundError{MissingIDs: missingIDs}
+// probeMissing identifies missing values for a selector whose values are not
+// always echoed in results. Values are de-duplicated, and values carried by a
+// returned row are found. Each remaining value returns at least one primary
+// row when it exists, so the remaining values hold a missing one only when
+// there are fewer such rows than values. That group is then bisected: one
+// half is looked up, the other half's row count follows from the difference,
+// and only halves that must hold a missing value are split further. Probes
+// draw from budget; once it is spent, groups that were not isolated are left
+// unreported. A value returning several primary rows can mask a missing value
+// in its group, as counts alone cannot tell them apart.
+func probeMissing[T comparable](
+	ctx context.Context,
+	c *Client,
+	req LookupRequest,
+	values []T,
+	results []ContentResult,
+	budget *int,
+	probe missingProbe[T],
+) ([]T, error) {
+	values = uniqueValues(values)
+	if len(values) == 0 {
+		return nil, nil
 	}
 
-	return &result, nil
+	var unresolved []T
+	for _, v := range values {
+		if !slices.ContainsFunc(results, func(r ContentResult) bool { return probe.echoes(r, v) }) {
+			unresolved = append(unresolved, v)
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil, nil
+	}
+
+	rows := 0
+	for _, r := range results {
+		if probe.isPrimary(r) && !slices.ContainsFunc(values, func(v T) bool { return probe.echoes(r, v) }) {
+			rows++
+		}
+	}
+
+	var bisect func(group []T, rows int) ([]T, error)
+	bisect = func(group []T, rows int) ([]T, error) {
+		if rows == 0 {
+			return group, nil
+		}
+		if rows >= len(group) {
+			return nil, nil
+		}
+		if *budget <= 0 {
+			if c.logger != nil {
+				c.logger.LogEvent(ctx, "Missing lookup values not identified within probe limit", map[string]any{
+					"values":      fmt.Sprint(group),
+					"max_missing": len(group) - rows,
+					"max_probes":  common.MaxMissingProbes,
+				})
+			}
+			return nil, nil
+		}
+		*budget--
+
+		left, right := group[:len(group)/2], group[len(group)/2:]
+		probeReq := LookupRequest{Country: req.Country, Limit: common.MaxLookupBatchSize}
+		probe.set(&probeReq, left)
+		result, err := c.fetchLookup(ctx, probeReq)
+		if err != nil {
+			return nil, err
+		}
+		leftRows := 0
+		for _, r := range result.Results {
+			if probe.isPrimary(r) {
+				leftRows++
+			}
+		}
+
+		missingLeft, err := bisect(left, leftRows)
+		if err != nil {
+			return nil, err
+		}
+		missingRight, err := bisect(right, max(rows-leftRows, 0))
+		if err != nil {
+			return nil, err
+		}
+		return append(missingLeft, missingRight...), nil
+	}
+
+	return bisect(unresolved, rows)
+}
+
+// uniqueValues returns values with duplicates removed, keeping the first of each.
+func uniqueValues[T comparable](values []T) []T {
+	seen := make(map[T]bool, len(values))
+	unique := make([]T, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// isCollectionRow reports whether the row is an album or other collection.
+func isCollectionRow(r ContentResult) bool {
+	return r.WrapperType == WrapperTypeCollection
+}
+
+// isEbookRow reports whether the row is an ebook.
+func isEbookRow(r ContentResult) bool {
+	return r.Kind == "ebook"
+}
+
+// isMusicVideoRow reports whether the row is a music video.
+func isMusicVideoRow(r ContentResult) bool {
+	return r.Kind == "music-video"
 }
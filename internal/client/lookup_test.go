@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
 )
 
 func TestLookup_Success(t *testing.T) {
@@ -109,3 +112,355 @@ func TestLookup_BundleIDs(t *testing.T) {
 		t.Errorf("expected 1 result, got %d", len(result.Results))
 	}
 }
+
+func TestLookup_DecodesCollectionAndTrackFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, `{"results":[
+			{"wrapperType":"artist","artistType":"Artist","artistId":909253,"amgArtistId":468749,"artistName":"Jack Johnson"},
+			{"wrapperType":"track","kind":"song","artistId":909253,"collectionId":1469577723,"trackId":1469577741,
+			 "artistName":"Jack Johnson","collectionName":"Jack Johnson and Friends","trackNumber":1,"trackCount":14,
+			 "discNumber":1,"trackTimeMillis":210743,"previewUrl":"https://example.com/preview.m4a",
+			 "collectionPrice":9.99,"trackExplicitness":"notExplicit","country":"USA","isStreamable":true}
+		]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	result, err := c.Lookup(context.Background(), LookupRequest{
+		AMGArtistIDs: []int64{468749},
+		Entity:       "song",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(result.Results))
+	}
+
+	artist := result.Results[0]
+	if artist.WrapperType != "artist" || artist.AMGArtistID != 468749 || artist.ArtistName != "Jack Johnson" {
+		t.Errorf("unexpected artist row: %+v", artist)
+	}
+
+	track := result.Results[1]
+	if track.CollectionID != 1469577723 || track.CollectionName != "Jack Johnson and Friends" {
+		t.Errorf("unexpected collection fields: %d %q", track.CollectionID, track.CollectionName)
+	}
+	if track.TrackNumber != 1 || track.TrackCount != 14 || track.DiscNumber != 1 || track.TrackTimeMillis != 210743 {
+		t.Errorf("unexpected track numbering fields: %+v", track)
+	}
+	if track.CollectionPrice != 9.99 || track.TrackExplicitness != "notExplicit" || !track.IsStreamable {
+		t.Errorf("unexpected track pricing fields: %+v", track)
+	}
+}
+
+func TestLookup_DecodesPodcastEpisodeGenres(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, `{"results":[
+			{"wrapperType":"podcastEpisode","kind":"podcast-episode","trackId":1000,
+			 "episodeUrl":"https://example.com/ep.mp3","feedUrl":"https://example.com/feed.xml",
+			 "genres":[{"name":"Comedy","id":"1303"}]}
+		]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	result, err := c.Lookup(context.Background(), LookupRequest{
+		IDs: []int64{1000},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	episode := result.Results[0]
+	if len(episode.Genres) != 1 || episode.Genres[0] != "Comedy" {
+		t.Errorf("expected genres [Comedy], got %v", episode.Genres)
+	}
+	if episode.EpisodeURL != "https://example.com/ep.mp3" || episode.FeedURL != "https://example.com/feed.xml" {
+		t.Errorf("unexpected podcast URLs: %q %q", episode.EpisodeURL, episode.FeedURL)
+	}
+}
+
+func TestLookup_FakeServerSelectors(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	result, err := c.Lookup(context.Background(), LookupRequest{
+		UPCs:      []string{"602547924636"},
+		ISBNs:     []string{"9780553418026"},
+		BundleIDs: []string{"com.apple.Pages"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(result.Results))
+	}
+	if result.Results[0].CollectionName != "In Between Dreams" {
+		t.Errorf("expected UPC match first, got %+v", result.Results[0])
+	}
+}
+
+func TestLookup_FakeServerEntityExpansion(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	result, err := c.Lookup(context.Background(), LookupRequest{AMGAlbumIDs: []int64{15175}, Entity: "song"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	typed := result.Typed()
+	if len(typed) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(typed))
+	}
+	if _, ok := typed[0].(*Collection); !ok {
+		t.Errorf("expected collection first, got %T", typed[0])
+	}
+	if _, ok := typed[1].(*Track); !ok {
+		t.Errorf("expected track second, got %T", typed[1])
+	}
+}
+
+func TestLookup_MissingBundleIDsAndAMGArtistIDs(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	result, err := c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.pages", "com.apple.Typo"}})
+	notFoundErr, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Errorf("expected found results to be returned, got %d", len(result.Results))
+	}
+	if len(notFoundErr.MissingBundleIDs) != 1 || notFoundErr.MissingBundleIDs[0] != "com.apple.Typo" {
+		t.Errorf("expected missing bundle ID com.apple.Typo, got %v", notFoundErr.MissingBundleIDs)
+	}
+
+	_, err = c.Lookup(context.Background(), LookupRequest{AMGArtistIDs: []int64{468749, 1}})
+	notFoundErr, ok = err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.MissingAMGIDs) != 1 || notFoundErr.MissingAMGIDs[0] != 1 {
+		t.Errorf("expected missing AMG ID 1, got %v", notFoundErr.MissingAMGIDs)
+	}
+}
+
+func TestLookup_IDsMatchCollectionsAndArtists(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1469577723, 909253}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLookup_MissingUPCsBisectsBatch(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{UPCs: []string{"000000000000", "602547924636"}, Entity: "song"})
+	notFoundErr, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.MissingUPCs) != 1 || notFoundErr.MissingUPCs[0] != "000000000000" {
+		t.Errorf("expected missing UPC 000000000000, got %v", notFoundErr.MissingUPCs)
+	}
+	if server.RequestCount() != 2 {
+		t.Errorf("expected 1 batch request and 1 probe, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_LimitBelowValuesSkipsMissingDetection(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{UPCs: []string{"000000000000", "602547924636"}, Limit: 1})
+	if err != nil {
+		t.Fatalf("expected no error when the limit may truncate results, got %v", err)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected no probes, got %d requests", server.RequestCount())
+	}
+}
+
+func TestLookup_EntityWithLimitSkipsMissingDetection(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1469577723, 999}, Entity: "song", Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error when an entity may truncate results, got %v", err)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected 1 request, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_LimitMatchingValuesReportsMissing(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1469577723, 999}, Limit: 2})
+	notFoundErr, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.MissingIDs) != 1 || notFoundErr.MissingIDs[0] != 999 {
+		t.Errorf("expected missing ID 999, got %v", notFoundErr.MissingIDs)
+	}
+}
+
+func TestLookup_MissingUPCProbesAreBounded(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	upcs := []string{"602547924636"}
+	for i := range 40 {
+		upcs = append(upcs, fmt.Sprintf("%012d", i))
+	}
+
+	_, err := c.Lookup(context.Background(), LookupRequest{UPCs: upcs})
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if got := server.RequestCount(); got > 1+common.MaxMissingProbes {
+		t.Errorf("expected at most %d requests, got %d", 1+common.MaxMissingProbes, got)
+	}
+}
+
+func TestLookup_DuplicateUPCIsNotReportedMissing(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{UPCs: []string{"602547924636", "602547924636"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected a single request, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_EchoedUPCsMatchRows(t *testing.T) {
+	server := fakeitunes.New(
+		fakeitunes.Item{WrapperType: "collection", CollectionID: 1, UPC: "111111111111", Fields: map[string]any{"upc": "111111111111"}},
+		fakeitunes.Item{WrapperType: "collection", CollectionID: 2, UPC: "111111111111", Fields: map[string]any{"upc": "111111111111"}},
+	)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{UPCs: []string{"111111111111", "000000000000"}})
+	notFoundErr, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.MissingUPCs) != 1 || notFoundErr.MissingUPCs[0] != "000000000000" {
+		t.Errorf("expected missing UPC 000000000000, got %v", notFoundErr.MissingUPCs)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected echoed UPCs to need no probes, got %d requests", server.RequestCount())
+	}
+}
+
+func TestLookup_AllUPCsFoundDoesNotProbe(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{UPCs: []string{"602547924636"}, ISBNs: []string{"9780553418026"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.RequestCount() != 1 {
+		t.Errorf("expected a single request, got %d", server.RequestCount())
+	}
+}
+
+func TestLookup_MissingISBNAndAMGVideoID(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	_, err := c.Lookup(context.Background(), LookupRequest{ISBNs: []string{"9780000000000"}, AMGVideoIDs: []int64{17120, 99}})
+	notFoundErr, ok := err.(*NotFoundError)
+	if !ok {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.MissingISBNs) != 1 || notFoundErr.MissingISBNs[0] != "9780000000000" {
+		t.Errorf("expected missing ISBN, got %v", notFoundErr.MissingISBNs)
+	}
+	if len(notFoundErr.MissingAMGIDs) != 1 || notFoundErr.MissingAMGIDs[0] != 99 {
+		t.Errorf("expected missing AMG video ID 99, got %v", notFoundErr.MissingAMGIDs)
+	}
+}
+
+func TestNotFoundError_MessageListsEverySelector(t *testing.T) {
+	err := &NotFoundError{MissingIDs: []int64{1}, MissingBundleIDs: []string{"com.example.app"}, MissingUPCs: []string{"123"}}
+	expected := "The following IDs were not found: [1]; The following bundle IDs were not found: [com.example.app]; The following UPCs were not found: [123]"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestLookup_TagsStorefront(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	result, err := c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Pages"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Results[0].Storefront != "us" {
+		t.Errorf("expected default storefront us, got %q", result.Results[0].Storefront)
+	}
+
+	result, err = c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Pages"}, Country: "GB"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Results[0].Storefront != "gb" {
+		t.Errorf("expected storefront gb, got %q", result.Results[0].Storefront)
+	}
+}
+
+func TestLookup_DefaultCountry(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := NewClientWithConfig(Config{BaseURL: server.URL, DefaultCountry: "GB"})
+
+	if c.DefaultCountry() != "gb" {
+		t.Errorf("expected default country gb, got %q", c.DefaultCountry())
+	}
+
+	result, err := c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Pages"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := server.Requests()[0].Query().Get("country"); got != "gb" {
+		t.Errorf("expected country=gb to be sent, got %q", got)
+	}
+	if result.Results[0].Storefront != "gb" {
+		t.Errorf("expected storefront gb, got %q", result.Results[0].Storefront)
+	}
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{BundleIDs: []string{"com.apple.Pages"}, Country: "de"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := server.Requests()[1].Query().Get("country"); got != "de" {
+		t.Errorf("expected explicit country to win, got %q", got)
+	}
+}
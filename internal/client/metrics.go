@@ -0,0 +1,182 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Operations reported in RequestMetrics.
+const (
+	OperationSearch  = "search"
+	OperationLookup  = "lookup"
+	OperationArtwork = "artwork"
+)
+
+// RequestMetrics describes a completed search, lookup, or artwork request.
+type RequestMetrics struct {
+	// Operation is one of OperationSearch, OperationLookup, or OperationArtwork.
+	Operation string
+	URL       string
+	// StatusCode is the status of the last HTTP response, or zero when none
+	// was received, such as for cache hits and shared requests.
+	StatusCode int
+	// Attempts is the number of HTTP requests sent, including retries.
+	Attempts int
+	// Latency is the total time taken, including rate limiter and retry waits.
+	Latency time.Duration
+	// RateLimitWait is the time spent waiting for the rate limiter.
+	RateLimitWait time.Duration
+	// CacheHit reports whether the body was served from the response cache.
+	CacheHit bool
+	// Shared reports whether the request joined an identical one in flight,
+	// whose own metrics are reported separately.
+	Shared bool
+	// Bytes is the size of the response body returned.
+	Bytes int
+	Err   error
+}
+
+// Retries returns the number of attempts after the first.
+func (m RequestMetrics) Retries() int {
+	return max(m.Attempts-1, 0)
+}
+
+// Hooks receives metrics for every search, lookup, and artwork request the
+// client completes. Implementations must be safe for concurrent use.
+type Hooks interface {
+	RequestCompleted(ctx context.Context, m RequestMetrics)
+}
+
+// multiHooks passes metrics to several Hooks in turn.
+type multiHooks []Hooks
+
+// MultiHooks returns Hooks that pass metrics to each of hooks in turn.
+func MultiHooks(hooks ...Hooks) Hooks {
+	return multiHooks(hooks)
+}
+
+// RequestCompleted implements Hooks.
+func (mh multiHooks) RequestCompleted(ctx context.Context, m RequestMetrics) {
+	for _, h := range mh {
+		h.RequestCompleted(ctx, m)
+	}
+}
+
+// SetHooks sets the hooks that receive request metrics.
+func (c *Client) SetHooks(hooks Hooks) {
+	c.hooks = hooks
+}
+
+// requestMetricsKey is the context key for the metrics of the request in progress.
+type requestMetricsKey struct{}
+
+// requestMetricsFrom returns the metrics being collected for the request in
+// ctx, or nil when no hooks are set.
+func requestMetricsFrom(ctx context.Context) *RequestMetrics {
+	m, _ := ctx.Value(requestMetricsKey{}).(*RequestMetrics)
+	return m
+}
+
+// observe runs fetch, collecting metrics for it from the request helpers, and
+// reports them to the hooks once it completes.
+func (c *Client) observe(ctx context.Context, operation, url string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if c.hooks == nil {
+		return fetch(ctx)
+	}
+
+	m := &RequestMetrics{Operation: operation, URL: url}
+	start := time.Now()
+	body, err := fetch(context.WithValue(ctx, requestMetricsKey{}, m))
+	m.Latency = time.Since(start)
+	m.Bytes = len(body)
+	m.Err = err
+	c.hooks.RequestCompleted(ctx, *m)
+	return body, err
+}
+
+// fileMetricsRecord is the JSON representation of RequestMetrics written by
+// FileHooks.
+type fileMetricsRecord struct {
+	Time            time.Time `json:"time"`
+	Operation       string    `json:"operation"`
+	URL             string    `json:"url"`
+	StatusCode      int       `json:"status_code,omitempty"`
+	Attempts        int       `json:"attempts"`
+	Retries         int       `json:"retries"`
+	LatencyMS       float64   `json:"latency_ms"`
+	RateLimitWaitMS float64   `json:"rate_limit_wait_ms"`
+	CacheHit        bool      `json:"cache_hit"`
+	Shared          bool      `json:"shared"`
+	Bytes           int       `json:"bytes"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// FileHooks appends the metrics of each request to a file as a line of JSON,
+// for analysis after a run. The file is opened in append mode for each line,
+// which is written with a single write, so several processes may append to
+// the same file and no handle is held between requests.
+type FileHooks struct {
+	path string
+}
+
+// NewFileHooks returns hooks appending to the file at path, creating it if
+// needed. It fails if the file cannot be opened for appending.
+func NewFileHooks(path string) (*FileHooks, error) {
+	fh := &FileHooks{path: path}
+	f, err := fh.open()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("error closing metrics file: %w", err)
+	}
+	return fh, nil
+}
+
+// open opens the metrics file for appending.
+func (fh *FileHooks) open() (*os.File, error) {
+	f, err := os.OpenFile(fh.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening metrics file: %w", err)
+	}
+	return f, nil
+}
+
+// RequestCompleted implements Hooks. Write errors are ignored, as metrics
+// must not fail requests.
+func (fh *FileHooks) RequestCompleted(ctx context.Context, m RequestMetrics) {
+	record := fileMetricsRecord{
+		Time:            time.Now().UTC(),
+		Operation:       m.Operation,
+		URL:             m.URL,
+		StatusCode:      m.StatusCode,
+		Attempts:        m.Attempts,
+		Retries:         m.Retries(),
+		LatencyMS:       float64(m.Latency) / float64(time.Millisecond),
+		RateLimitWaitMS: float64(m.RateLimitWait) / float64(time.Millisecond),
+		CacheHit:        m.CacheHit,
+		Shared:          m.Shared,
+		Bytes:           m.Bytes,
+	}
+	if m.Err != nil {
+		record.Error = m.Err.Error()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	f, err := fh.open()
+	if err != nil {
+		return
+	}
+	_, _ = f.Write(append(line, '\n'))
+	_ = f.Close()
+}
@@ -0,0 +1,263 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingHooks collects the metrics reported to it.
+type recordingHooks struct {
+	mu      sync.Mutex
+	metrics []RequestMetrics
+}
+
+func (h *recordingHooks) RequestCompleted(ctx context.Context, m RequestMetrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.metrics = append(h.metrics, m)
+}
+
+func (h *recordingHooks) all() []RequestMetrics {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]RequestMetrics(nil), h.metrics...)
+}
+
+func TestHooks_RecordRetriesAndStatus(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[{"trackId":1}]}`)
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	c := newTestClient(server.URL)
+	c.SetHooks(hooks)
+
+	if _, err := c.Lookup(context.Background(), LookupRequest{IDs: []int64{1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metrics := hooks.all()
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(metrics))
+	}
+	m := metrics[0]
+	if m.Operation != OperationLookup || m.StatusCode != http.StatusOK || m.Attempts != 2 || m.Retries() != 1 {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+	if m.Bytes != len(`{"results":[{"trackId":1}]}`) || m.CacheHit || m.Shared || m.Err != nil {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+	if m.Latency < time.Second {
+		t.Errorf("expected latency to include the retry wait, got %s", m.Latency)
+	}
+}
+
+func TestHooks_RecordRateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	c := NewClientWithConfig(Config{BaseURL: server.URL, RateLimitRequests: 1, RateLimitDuration: 100 * time.Millisecond})
+	c.SetHooks(hooks)
+
+	for _, term := range []string{"first", "second"} {
+		if _, err := c.Search(context.Background(), SearchRequest{Term: term}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	metrics := hooks.all()
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(metrics))
+	}
+	if metrics[0].Operation != OperationSearch {
+		t.Errorf("expected search operation, got %q", metrics[0].Operation)
+	}
+	if metrics[1].RateLimitWait < 50*time.Millisecond {
+		t.Errorf("expected the second search to wait for the rate limiter, waited %s", metrics[1].RateLimitWait)
+	}
+}
+
+func TestHooks_RecordCacheHits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	c := NewClientWithConfig(Config{BaseURL: server.URL, CacheDir: t.TempDir()})
+	c.SetHooks(hooks)
+
+	for range 2 {
+		if _, err := c.Search(context.Background(), SearchRequest{Term: "x"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	metrics := hooks.all()
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(metrics))
+	}
+	if metrics[0].CacheHit || metrics[0].Attempts != 1 {
+		t.Errorf("expected the first search to reach the API, got %+v", metrics[0])
+	}
+	if !metrics[1].CacheHit || metrics[1].Attempts != 0 || metrics[1].StatusCode != 0 {
+		t.Errorf("expected the second search to be a cache hit, got %+v", metrics[1])
+	}
+}
+
+func TestHooks_RecordSharedRequests(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	c := newTestClient(server.URL)
+	c.SetHooks(hooks)
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			if _, err := c.Search(context.Background(), SearchRequest{Term: "x"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+	waitForWaiters(t, &c.flights, 2)
+	close(release)
+	wg.Wait()
+
+	var shared, sent int
+	for _, m := range hooks.all() {
+		if m.Shared {
+			shared++
+		} else if m.Attempts == 1 {
+			sent++
+		}
+	}
+	if shared != 1 || sent != 1 {
+		t.Errorf("expected 1 sent and 1 shared event, got %+v", hooks.all())
+	}
+}
+
+func TestHooks_RecordArtwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("image-bytes"))
+	}))
+	defer server.Close()
+
+	hooks := &recordingHooks{}
+	c := newTestClient(server.URL)
+	c.SetHooks(hooks)
+
+	if _, err := c.FetchArtwork(context.Background(), server.URL+"/art.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metrics := hooks.all()
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(metrics))
+	}
+	if m := metrics[0]; m.Operation != OperationArtwork || m.Bytes != len("image-bytes") || m.RateLimitWait != 0 {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+}
+
+func TestFileHooks_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	fh, err := NewFileHooks(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hooks := MultiHooks(fh, &recordingHooks{})
+	hooks.RequestCompleted(context.Background(), RequestMetrics{
+		Operation:  OperationLookup,
+		URL:        "https://itunes.apple.com/lookup?id=1",
+		StatusCode: http.StatusTooManyRequests,
+		Attempts:   3,
+		Latency:    1500 * time.Millisecond,
+		Err:        ErrRateLimited,
+	})
+	hooks.RequestCompleted(context.Background(), RequestMetrics{Operation: OperationSearch, CacheHit: true, Bytes: 42})
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []fileMetricsRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record fileMetricsRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if r := records[0]; r.Operation != OperationLookup || r.Retries != 2 || r.LatencyMS != 1500 || r.Error != ErrRateLimited.Error() {
+		t.Errorf("unexpected first record %+v", r)
+	}
+	if r := records[1]; !r.CacheHit || r.Bytes != 42 || r.Error != "" {
+		t.Errorf("unexpected second record %+v", r)
+	}
+}
+
+func TestFileHooks_ReopensFileForEachRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	fh, err := NewFileHooks(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fh.RequestCompleted(context.Background(), RequestMetrics{Operation: OperationLookup})
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	fh.RequestCompleted(context.Background(), RequestMetrics{Operation: OperationSearch})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the file to be recreated after rotation: %v", err)
+	}
+	var record fileMetricsRecord
+	if err := json.Unmarshal(data, &record); err != nil || record.Operation != OperationSearch {
+		t.Errorf("expected a single search record in the new file, got %q (%v)", data, err)
+	}
+}
+
+func TestNewFileHooks_UnwritablePath(t *testing.T) {
+	if _, err := NewFileHooks(filepath.Join(t.TempDir(), "missing", "metrics.jsonl")); err == nil {
+		t.Fatal("expected an error for a file in a missing directory")
+	}
+}
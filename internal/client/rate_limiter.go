@@ -7,28 +7,92 @@ import (
 	"context"
 	"sync"
 	"time"
+)
+
+// Rate limiter backends selectable through Config.RateLimitBackend.
+const (
+	// RateLimitBackendMemory keeps the token bucket in the provider process.
+	RateLimitBackendMemory = "memory"
+	// RateLimitBackendFile keeps the token bucket in a file shared, under an
+	// exclusive lock, by every provider process on the host.
+	RateLimitBackendFile = "file"
+)
+
+// RateLimitBackends lists the supported rate limiter backends.
+var RateLimitBackends = []string{RateLimitBackendMemory, RateLimitBackendFile}
+
+// The limiters adapt their refill rate to rate-limited responses using
+// additive increase, multiplicative decrease (AIMD): an HTTP 429 halves the
+// rate, down to a floor, and each success recovers a small step of the
+// configured rate. 429s to requests sent before the last decrease belong to
+// the same burst and do not lower the rate again.
+const (
+	rateDecreaseFactor = 0.5
+	rateIncreaseStep   = 0.05
+	minRateFactor      = 0.05
+)
+
+// rateLimiter paces requests to the API.
+type rateLimiter interface {
+	// take blocks until a request may be sent or the context is cancelled.
+	take(ctx context.Context) error
+	// backOff reports a rate-limited response to a request sent at sent. It
+	// holds every caller for pause and, unless the request was sent before
+	// the last decrease, lowers the refill rate. It returns the rate in
+	// requests per second and whether it changed.
+	backOff(ctx context.Context, sent time.Time, pause time.Duration) (float64, bool, error)
+	// recover reports a successful response, raising the refill rate back
+	// towards the configured rate. It returns the rate in requests per second
+	// and whether it changed.
+	recover(ctx context.Context) (float64, bool, error)
+}
 
-	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+// Ensure both backends satisfy the rateLimiter interface.
+var (
+	_ rateLimiter = (*tokenBucket)(nil)
+	_ rateLimiter = (*fileTokenBucket)(nil)
 )
 
-// tokenBucket implements a simple token bucket rate limiter.
+// newRateLimiter returns the limiter for the configured backend, falling back
+// to the in-memory bucket when no shared file is configured.
+func newRateLimiter(cfg Config) rateLimiter {
+	if cfg.RateLimitBackend == RateLimitBackendFile && cfg.RateLimitFile != "" {
+		return newFileTokenBucket(cfg.RateLimitFile, cfg.RateLimitRequests, cfg.RateLimitDuration)
+	}
+	return newTokenBucket(cfg.RateLimitRequests, cfg.RateLimitDuration)
+}
+
+// decreaseRate returns the refill rate after a rate-limited response.
+func decreaseRate(rate, baseRate float64) float64 {
+	return max(rate*rateDecreaseFactor, baseRate*minRateFactor)
+}
+
+// increaseRate returns the refill rate after a successful response.
+func increaseRate(rate, baseRate float64) float64 {
+	return min(rate+baseRate*rateIncreaseStep, baseRate)
+}
+
+// tokenBucket implements a token bucket rate limiter whose refill rate adapts
+// to rate-limited responses.
 type tokenBucket struct {
 	tokens         float64
 	maxTokens      float64
+	baseRate       float64
 	refillRate     float64
 	lastRefillTime time.Time
+	lastDecrease   time.Time
+	pausedUntil    time.Time
 	mu             sync.Mutex
 }
 
-// newTokenBucket creates a new token bucket rate limiter using the configured
-// rate limit constants.
-func newTokenBucket() *tokenBucket {
-	maxRequests := common.RateLimitRequests
-	perDuration := common.RateLimitDuration
+// newTokenBucket creates a new token bucket rate limiter that allows maxRequests
+// requests per perDuration.
+func newTokenBucket(maxRequests int, perDuration time.Duration) *tokenBucket {
 	refillRate := float64(maxRequests) / perDuration.Seconds()
 	return &tokenBucket{
 		tokens:         float64(maxRequests),
 		maxTokens:      float64(maxRequests),
+		baseRate:       refillRate,
 		refillRate:     refillRate,
 		lastRefillTime: time.Now(),
 	}
@@ -40,22 +104,24 @@ func (tb *tokenBucket) take(ctx context.Context) error {
 	for {
 		tb.mu.Lock()
 		now := time.Now()
-		elapsed := now.Sub(tb.lastRefillTime).Seconds()
 
-		tb.tokens += elapsed * tb.refillRate
-		if tb.tokens > tb.maxTokens {
-			tb.tokens = tb.maxTokens
-		}
-		tb.lastRefillTime = now
+		var waitDuration time.Duration
+		if now.Before(tb.pausedUntil) {
+			waitDuration = tb.pausedUntil.Sub(now)
+		} else {
+			elapsed := max(now.Sub(tb.lastRefillTime).Seconds(), 0)
+			tb.tokens = min(tb.tokens+elapsed*tb.refillRate, tb.maxTokens)
+			tb.lastRefillTime = now
 
-		if tb.tokens >= 1.0 {
-			tb.tokens -= 1.0
-			tb.mu.Unlock()
-			return nil
-		}
+			if tb.tokens >= 1.0 {
+				tb.tokens -= 1.0
+				tb.mu.Unlock()
+				return nil
+			}
 
-		tokensNeeded := 1.0 - tb.tokens
-		waitDuration := time.Duration(tokensNeeded / tb.refillRate * float64(time.Second))
+			tokensNeeded := 1.0 - tb.tokens
+			waitDuration = time.Duration(tokensNeeded / tb.refillRate * float64(time.Second))
+		}
 		tb.mu.Unlock()
 
 		select {
@@ -65,3 +131,39 @@ func (tb *tokenBucket) take(ctx context.Context) error {
 		}
 	}
 }
+
+// backOff empties the bucket and holds every caller until pause has elapsed.
+// The refill rate is halved once per burst of rate-limited responses.
+func (tb *tokenBucket) backOff(ctx context.Context, sent time.Time, pause time.Duration) (float64, bool, error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+	changed := false
+	if !sent.Before(tb.lastDecrease) {
+		rate := decreaseRate(tb.refillRate, tb.baseRate)
+		changed = rate != tb.refillRate
+		tb.refillRate = rate
+		tb.lastDecrease = now
+	}
+	tb.tokens = 0
+	if until := now.Add(pause); until.After(tb.pausedUntil) {
+		tb.pausedUntil = until
+	}
+	if tb.pausedUntil.After(tb.lastRefillTime) {
+		tb.lastRefillTime = tb.pausedUntil
+	}
+	return tb.refillRate, changed, nil
+}
+
+// recover raises the refill rate by one step, up to the configured rate.
+func (tb *tokenBucket) recover(ctx context.Context) (float64, bool, error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if tb.refillRate >= tb.baseRate {
+		return tb.refillRate, false, nil
+	}
+	tb.refillRate = increaseRate(tb.refillRate, tb.baseRate)
+	return tb.refillRate, true, nil
+}
@@ -0,0 +1,197 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileBucketState is the on-disk representation of a shared token bucket.
+// Rate is the adapted refill rate in requests per second; zero means the
+// configured rate. Decreased is when the rate was last lowered.
+type fileBucketState struct {
+	Tokens      float64   `json:"tokens"`
+	Rate        float64   `json:"rate,omitempty"`
+	Updated     time.Time `json:"updated"`
+	Decreased   time.Time `json:"decreased,omitzero"`
+	PausedUntil time.Time `json:"paused_until,omitzero"`
+}
+
+// fileTokenBucket is a token bucket whose state lives in a file, so that any
+// number of provider processes on one host draw from a single budget and
+// adapt to rate-limited responses together. Every operation holds an
+// exclusive lock on a sibling .lock file while it reads and writes the state.
+// Processes sharing a file should use the same limits, as each refills the
+// bucket relative to its own configured rate.
+type fileTokenBucket struct {
+	path      string
+	maxTokens float64
+	baseRate  float64
+
+	// rate is the shared refill rate as of the last update, or zero before
+	// the first. It lets recover skip the file while the rate is at the
+	// configured rate; take refreshes it, so a backoff by another process is
+	// seen before the next response is reported.
+	mu   sync.Mutex
+	rate float64
+}
+
+// newFileTokenBucket creates a shared token bucket backed by the file at path
+// that allows maxRequests requests per perDuration across all processes.
+func newFileTokenBucket(path string, maxRequests int, perDuration time.Duration) *fileTokenBucket {
+	return &fileTokenBucket{
+		path:      path,
+		maxTokens: float64(maxRequests),
+		baseRate:  float64(maxRequests) / perDuration.Seconds(),
+	}
+}
+
+// take attempts to take a token from the shared bucket, blocking until one is
+// available or the context is cancelled. The lock is not held while waiting.
+func (fb *fileTokenBucket) take(ctx context.Context) error {
+	for {
+		var waitDuration time.Duration
+		acquired := false
+		err := fb.update(ctx, func(state *fileBucketState, now time.Time) {
+			if now.Before(state.PausedUntil) {
+				waitDuration = state.PausedUntil.Sub(now)
+				return
+			}
+
+			elapsed := max(now.Sub(state.Updated).Seconds(), 0)
+			state.Tokens = min(state.Tokens+elapsed*state.Rate, fb.maxTokens)
+			state.Updated = now
+
+			if state.Tokens >= 1.0 {
+				state.Tokens -= 1.0
+				acquired = true
+				return
+			}
+			tokensNeeded := 1.0 - state.Tokens
+			waitDuration = time.Duration(tokensNeeded / state.Rate * float64(time.Second))
+		})
+		if err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+		// A nearly full token can round down to no wait at all, which would
+		// otherwise retry the lock in a tight loop.
+		waitDuration = max(waitDuration, time.Millisecond)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitDuration):
+		}
+	}
+}
+
+// backOff empties the shared bucket and holds every caller in every process
+// until pause has elapsed. The shared refill rate is halved once per burst of
+// rate-limited responses.
+func (fb *fileTokenBucket) backOff(ctx context.Context, sent time.Time, pause time.Duration) (float64, bool, error) {
+	var rate float64
+	changed := false
+	err := fb.update(ctx, func(state *fileBucketState, now time.Time) {
+		if !sent.Before(state.Decreased) {
+			decreased := decreaseRate(state.Rate, fb.baseRate)
+			changed = decreased != state.Rate
+			state.Rate = decreased
+			state.Decreased = now
+		}
+		state.Tokens = 0
+		if until := now.Add(pause); until.After(state.PausedUntil) {
+			state.PausedUntil = until
+		}
+		if state.PausedUntil.After(state.Updated) {
+			state.Updated = state.PausedUntil
+		}
+		rate = state.Rate
+	})
+	return rate, changed, err
+}
+
+// recover raises the shared refill rate by one step, up to the configured
+// rate. While the rate is known to be at the configured rate, the file is not
+// touched.
+func (fb *fileTokenBucket) recover(ctx context.Context) (float64, bool, error) {
+	fb.mu.Lock()
+	rate := fb.rate
+	fb.mu.Unlock()
+	if rate >= fb.baseRate {
+		return rate, false, nil
+	}
+
+	changed := false
+	err := fb.update(ctx, func(state *fileBucketState, now time.Time) {
+		if state.Rate < fb.baseRate {
+			state.Rate = increaseRate(state.Rate, fb.baseRate)
+			changed = true
+		}
+		rate = state.Rate
+	})
+	return rate, changed, err
+}
+
+// update applies fn to the bucket state while holding the lock, then saves it.
+func (fb *fileTokenBucket) update(ctx context.Context, fn func(state *fileBucketState, now time.Time)) error {
+	if err := os.MkdirAll(filepath.Dir(fb.path), 0o700); err != nil {
+		return fmt.Errorf("error creating rate limit directory: %w", err)
+	}
+
+	lock, err := acquireFileLock(ctx, fb.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	now := time.Now()
+	state := fb.read(now)
+	if state.Rate <= 0 || state.Rate > fb.baseRate {
+		state.Rate = fb.baseRate
+	}
+	fn(&state, now)
+
+	fb.mu.Lock()
+	fb.rate = state.Rate
+	fb.mu.Unlock()
+
+	return fb.write(state)
+}
+
+// read loads the bucket state. A missing or unreadable file yields a full
+// bucket, as it does for the first process to use the file.
+func (fb *fileTokenBucket) read(now time.Time) fileBucketState {
+	full := fileBucketState{Tokens: fb.maxTokens, Updated: now}
+
+	data, err := os.ReadFile(fb.path)
+	if err != nil {
+		return full
+	}
+	var state fileBucketState
+	if err := json.Unmarshal(data, &state); err != nil || state.Updated.IsZero() {
+		return full
+	}
+	return state
+}
+
+// write saves the bucket state. Callers must hold the lock.
+func (fb *fileTokenBucket) write(state fileBucketState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error encoding rate limit state: %w", err)
+	}
+	if err := os.WriteFile(fb.path, data, 0o600); err != nil {
+		return fmt.Errorf("error writing rate limit state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
+
+func TestFileTokenBucket_SharedBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits", "bucket.json")
+	buckets := []*fileTokenBucket{
+		newFileTokenBucket(path, 6, time.Hour),
+		newFileTokenBucket(path, 6, time.Hour),
+		newFileTokenBucket(path, 6, time.Hour),
+	}
+
+	var wg sync.WaitGroup
+	var taken atomic.Int32
+	for _, fb := range buckets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 2 {
+				if err := fb.take(context.Background()); err != nil {
+					t.Errorf("take failed: %v", err)
+					return
+				}
+				taken.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if taken.Load() != 6 {
+		t.Fatalf("expected 6 tokens taken, got %d", taken.Load())
+	}
+
+	for i, fb := range buckets {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		err := fb.take(ctx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("bucket %d: expected the shared budget to be exhausted, got %v", i, err)
+		}
+	}
+}
+
+func TestFileTokenBucket_RefillOverTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	first := newFileTokenBucket(path, 1, 50*time.Millisecond)
+	second := newFileTokenBucket(path, 1, 50*time.Millisecond)
+
+	if err := first.take(context.Background()); err != nil {
+		t.Fatalf("first take failed: %v", err)
+	}
+
+	start := time.Now()
+	if err := second.take(context.Background()); err != nil {
+		t.Fatalf("second take failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("expected the second take to wait for a refill, waited %s", elapsed)
+	}
+}
+
+func TestFileTokenBucket_CorruptStateStartsFull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fb := newFileTokenBucket(path, 2, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := range 2 {
+		if err := fb.take(ctx); err != nil {
+			t.Fatalf("take %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestFileTokenBucket_NearlyFullTokenIsNotFree(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	fb := newFileTokenBucket(path, 1, time.Hour)
+	// An update time in the future stops the first attempt refilling, so the
+	// missing sliver of a token rounds down to a zero wait.
+	if err := fb.write(fileBucketState{Tokens: 1 - 1e-15, Rate: fb.baseRate, Updated: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := fb.take(ctx); err != nil {
+		t.Fatalf("take failed: %v", err)
+	}
+
+	if state := fb.read(time.Now()); state.Tokens >= 0.5 {
+		t.Errorf("expected the token to be taken from the shared state, got %v tokens", state.Tokens)
+	}
+}
+
+func TestClient_FileRateLimitBackendSharedBetweenClients(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	cfg := Config{
+		BaseURL:           server.URL,
+		RateLimitRequests: 3,
+		RateLimitDuration: time.Hour,
+		RateLimitBackend:  RateLimitBackendFile,
+		RateLimitFile:     filepath.Join(t.TempDir(), "rate-limit.json"),
+	}
+	clients := []*Client{NewClientWithConfig(cfg), NewClientWithConfig(cfg), NewClientWithConfig(cfg)}
+
+	req := LookupRequest{BundleIDs: []string{"com.apple.Keynote"}}
+	for i, c := range clients {
+		if _, err := c.Lookup(context.Background(), req); err != nil {
+			t.Fatalf("client %d: unexpected error: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := clients[0].Lookup(ctx, req)
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("expected the fourth request to wait on the shared budget, got %v", err)
+	}
+	if server.RequestCount() != 3 {
+		t.Errorf("expected 3 requests to reach the API, got %d", server.RequestCount())
+	}
+}
+
+func TestNewRateLimiter_Backends(t *testing.T) {
+	cfg := DefaultConfig()
+	if _, ok := newRateLimiter(cfg).(*tokenBucket); !ok {
+		t.Error("expected the in-memory bucket by default")
+	}
+
+	cfg.RateLimitBackend = RateLimitBackendFile
+	if _, ok := newRateLimiter(cfg).(*tokenBucket); !ok {
+		t.Error("expected the in-memory bucket when no file is configured")
+	}
+
+	cfg.RateLimitFile = filepath.Join(t.TempDir(), "bucket.json")
+	if _, ok := newRateLimiter(cfg).(*fileTokenBucket); !ok {
+		t.Error("expected the file bucket when a file is configured")
+	}
+}
+
+func TestFileTokenBucket_BackOffSharedBetweenInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	first := newFileTokenBucket(path, 20, time.Minute)
+	second := newFileTokenBucket(path, 20, time.Minute)
+
+	rate, _, err := first.backOff(context.Background(), time.Now(), 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("backOff failed: %v", err)
+	}
+	if rate != first.baseRate/2 {
+		t.Errorf("expected rate %v, got %v", first.baseRate/2, rate)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := second.take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the other instance to be paused, got %v", err)
+	}
+
+	rate, _, err = second.recover(context.Background())
+	if err != nil {
+		t.Fatalf("recover failed: %v", err)
+	}
+	if want := first.baseRate/2 + first.baseRate*rateIncreaseStep; rate != want {
+		t.Errorf("expected the other instance to recover from the shared rate %v, got %v", want, rate)
+	}
+}
+
+func TestFileTokenBucket_RecoverAtConfiguredRateSkipsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	fb := newFileTokenBucket(path, 20, time.Minute)
+	if err := fb.take(context.Background()); err != nil {
+		t.Fatalf("take failed: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading state: %v", err)
+	}
+
+	rate, changed, err := fb.recover(context.Background())
+	if err != nil {
+		t.Fatalf("recover failed: %v", err)
+	}
+	if changed || rate != fb.baseRate {
+		t.Errorf("expected the rate to stay at %v, got %v (changed=%v)", fb.baseRate, rate, changed)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading state: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("expected recover at the configured rate not to rewrite the state, got %s then %s", before, after)
+	}
+}
+
+func TestFileTokenBucket_BackOffLowersRateOncePerBurst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bucket.json")
+	first := newFileTokenBucket(path, 20, time.Minute)
+	second := newFileTokenBucket(path, 20, time.Minute)
+	sent := time.Now()
+
+	if _, changed, err := first.backOff(context.Background(), sent, 0); err != nil || !changed {
+		t.Fatalf("expected the first 429 to lower the rate, got changed=%v err=%v", changed, err)
+	}
+	rate, changed, err := second.backOff(context.Background(), sent, 0)
+	if err != nil {
+		t.Fatalf("backOff failed: %v", err)
+	}
+	if changed || rate != first.baseRate/2 {
+		t.Errorf("expected a 429 from the same burst in another process to leave the rate at %v, got %v (changed=%v)", first.baseRate/2, rate, changed)
+	}
+}
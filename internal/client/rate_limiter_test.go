@@ -7,10 +7,12 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
 )
 
 func TestTokenBucket_InitialTokensAvailable(t *testing.T) {
-	tb := newTokenBucket()
+	tb := newTokenBucket(common.RateLimitRequests, common.RateLimitDuration)
 	ctx := context.Background()
 
 	for i := range 5 {
@@ -55,3 +57,84 @@ func TestTokenBucket_RefillOverTime(t *testing.T) {
 		t.Fatalf("take after refill failed: %v", err)
 	}
 }
+
+func TestTokenBucket_BackOffHalvesRateDownToFloor(t *testing.T) {
+	tb := newTokenBucket(20, time.Minute)
+	base := tb.baseRate
+
+	rate, _, _ := tb.backOff(context.Background(), time.Now(), 0)
+	if rate != base/2 {
+		t.Errorf("expected rate %v after one 429, got %v", base/2, rate)
+	}
+	if tb.tokens != 0 {
+		t.Errorf("expected the bucket to be emptied, got %v tokens", tb.tokens)
+	}
+
+	for range 10 {
+		rate, _, _ = tb.backOff(context.Background(), time.Now(), 0)
+	}
+	if rate != base*minRateFactor {
+		t.Errorf("expected rate to stop at the floor %v, got %v", base*minRateFactor, rate)
+	}
+}
+
+func TestTokenBucket_RecoverStepsBackToConfiguredRate(t *testing.T) {
+	tb := newTokenBucket(20, time.Minute)
+	base := tb.baseRate
+	_, _, _ = tb.backOff(context.Background(), time.Now(), 0)
+
+	rate, _, _ := tb.recover(context.Background())
+	if want := base/2 + base*rateIncreaseStep; rate != want {
+		t.Errorf("expected rate %v after one success, got %v", want, rate)
+	}
+
+	for range 100 {
+		rate, _, _ = tb.recover(context.Background())
+	}
+	if rate != base {
+		t.Errorf("expected rate to recover to %v, got %v", base, rate)
+	}
+}
+
+func TestTokenBucket_BackOffLowersRateOncePerBurst(t *testing.T) {
+	tb := newTokenBucket(20, time.Minute)
+	base := tb.baseRate
+	sent := time.Now()
+
+	if rate, changed, _ := tb.backOff(context.Background(), sent, 0); !changed || rate != base/2 {
+		t.Fatalf("expected the first 429 to halve the rate to %v, got %v (changed=%v)", base/2, rate, changed)
+	}
+	if rate, changed, _ := tb.backOff(context.Background(), sent, 0); changed || rate != base/2 {
+		t.Errorf("expected a 429 from the same burst to leave the rate at %v, got %v (changed=%v)", base/2, rate, changed)
+	}
+	if rate, changed, _ := tb.backOff(context.Background(), time.Now(), 0); !changed || rate != base/4 {
+		t.Errorf("expected a 429 to a later request to halve the rate to %v, got %v (changed=%v)", base/4, rate, changed)
+	}
+}
+
+func TestTokenBucket_RecoverAtConfiguredRateIsUnchanged(t *testing.T) {
+	tb := newTokenBucket(20, time.Minute)
+
+	if rate, changed, _ := tb.recover(context.Background()); changed || rate != tb.baseRate {
+		t.Errorf("expected the rate to stay at %v, got %v (changed=%v)", tb.baseRate, rate, changed)
+	}
+}
+
+func TestTokenBucket_BackOffPausesWaitingCallers(t *testing.T) {
+	tb := newTokenBucket(1000, time.Second)
+	_, _, _ = tb.backOff(context.Background(), time.Now(), 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tb.take(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected take to wait out the pause, got %v", err)
+	}
+
+	start := time.Now()
+	if err := tb.take(context.Background()); err != nil {
+		t.Fatalf("take after pause failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("expected take to wait for the rest of the pause, waited %s", elapsed)
+	}
+}
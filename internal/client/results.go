@@ -0,0 +1,324 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+// Wrapper types returned by the iTunes Search API in the wrapperType field.
+const (
+	WrapperTypeArtist         = "artist"
+	WrapperTypeCollection     = "collection"
+	WrapperTypeTrack          = "track"
+	WrapperTypeSoftware       = "software"
+	WrapperTypeAudiobook      = "audiobook"
+	WrapperTypePodcastEpisode = "podcastEpisode"
+)
+
+// Result is implemented by every typed result variant.
+type Result interface {
+	// WrapperType returns the iTunes wrapperType the variant was decoded from.
+	WrapperType() string
+	// ID returns the primary iTunes identifier of the result.
+	ID() int64
+	// Name returns the display name of the result.
+	Name() string
+}
+
+// Artist is an artist, author, or developer row.
+type Artist struct {
+	ArtistID       int64
+	AMGArtistID    int64
+	ArtistName     string
+	ArtistType     string
+	ArtistLinkURL  string
+	PrimaryGenre   string
+	PrimaryGenreID int64
+}
+
+// Collection is an album, compilation, or other collection row.
+type Collection struct {
+	CollectionID           int64
+	CollectionName         string
+	CollectionType         string
+	CollectionViewURL      string
+	ArtistID               int64
+	AMGArtistID            int64
+	ArtistName             string
+	ArtworkURL             string
+	CollectionPrice        float64
+	Currency               string
+	CollectionExplicitness string
+	TrackCount             int64
+	Copyright              string
+	Country                string
+	ReleaseDate            string
+	PrimaryGenre           string
+}
+
+// Track is a song, music video, movie, TV episode, podcast, or ebook row.
+type Track struct {
+	Kind              string
+	TrackID           int64
+	TrackName         string
+	TrackViewURL      string
+	ArtistID          int64
+	ArtistName        string
+	CollectionID      int64
+	CollectionName    string
+	TrackNumber       int64
+	TrackCount        int64
+	DiscNumber        int64
+	DiscCount         int64
+	TrackTimeMillis   int64
+	PreviewURL        string
+	ArtworkURL        string
+	TrackPrice        float64
+	Currency          string
+	TrackExplicitness string
+	ReleaseDate       string
+	PrimaryGenre      string
+}
+
+// Software is an iOS, iPadOS, macOS, or tvOS app row.
+type Software struct {
+	TrackID                   int64
+	TrackName                 string
+	TrackViewURL              string
+	BundleID                  string
+	SellerName                string
+	Version                   string
+	MinimumOSVersion          string
+	FileSizeBytes             string
+	Price                     float64
+	FormattedPrice            string
+	Currency                  string
+	ReleaseDate               string
+	CurrentVersionReleaseDate string
+	PrimaryGenre              string
+	AverageRating             float64
+	RatingCount               int64
+}
+
+// Audiobook is an audiobook row.
+type Audiobook struct {
+	CollectionID           int64
+	CollectionName         string
+	CollectionViewURL      string
+	ArtistID               int64
+	ArtistName             string
+	ArtworkURL             string
+	CollectionPrice        float64
+	Currency               string
+	CollectionExplicitness string
+	TrackCount             int64
+	Description            string
+	PreviewURL             string
+	ReleaseDate            string
+	PrimaryGenre           string
+}
+
+// PodcastEpisode is a podcast episode row.
+type PodcastEpisode struct {
+	TrackID          int64
+	TrackName        string
+	TrackViewURL     string
+	CollectionID     int64
+	CollectionName   string
+	FeedURL          string
+	EpisodeURL       string
+	EpisodeGUID      string
+	TrackTimeMillis  int64
+	ArtworkURL       string
+	ShortDescription string
+	ReleaseDate      string
+}
+
+// Ensure every variant satisfies the Result interface.
+var (
+	_ Result = (*Artist)(nil)
+	_ Result = (*Collection)(nil)
+	_ Result = (*Track)(nil)
+	_ Result = (*Software)(nil)
+	_ Result = (*Audiobook)(nil)
+	_ Result = (*PodcastEpisode)(nil)
+)
+
+// WrapperType implements Result.
+func (a *Artist) WrapperType() string { return WrapperTypeArtist }
+
+// ID implements Result.
+func (a *Artist) ID() int64 { return a.ArtistID }
+
+// Name implements Result.
+func (a *Artist) Name() string { return a.ArtistName }
+
+// WrapperType implements Result.
+func (c *Collection) WrapperType() string { return WrapperTypeCollection }
+
+// ID implements Result.
+func (c *Collection) ID() int64 { return c.CollectionID }
+
+// Name implements Result.
+func (c *Collection) Name() string { return c.CollectionName }
+
+// WrapperType implements Result.
+func (t *Track) WrapperType() string { return WrapperTypeTrack }
+
+// ID implements Result.
+func (t *Track) ID() int64 { return t.TrackID }
+
+// Name implements Result.
+func (t *Track) Name() string { return t.TrackName }
+
+// WrapperType implements Result.
+func (s *Software) WrapperType() string { return WrapperTypeSoftware }
+
+// ID implements Result.
+func (s *Software) ID() int64 { return s.TrackID }
+
+// Name implements Result.
+func (s *Software) Name() string { return s.TrackName }
+
+// WrapperType implements Result.
+func (a *Audiobook) WrapperType() string { return WrapperTypeAudiobook }
+
+// ID implements Result.
+func (a *Audiobook) ID() int64 { return a.CollectionID }
+
+// Name implements Result.
+func (a *Audiobook) Name() string { return a.CollectionName }
+
+// WrapperType implements Result.
+func (p *PodcastEpisode) WrapperType() string { return WrapperTypePodcastEpisode }
+
+// ID implements Result.
+func (p *PodcastEpisode) ID() int64 { return p.TrackID }
+
+// Name implements Result.
+func (p *PodcastEpisode) Name() string { return p.TrackName }
+
+// Typed returns the response rows decoded into their typed variants, in order.
+func (r *ContentResponse) Typed() []Result {
+	return TypedResults(r.Results)
+}
+
+// TypedResults converts each row into the variant selected by its wrapperType.
+func TypedResults(results []ContentResult) []Result {
+	typed := make([]Result, 0, len(results))
+	for _, result := range results {
+		typed = append(typed, NewResult(result))
+	}
+	return typed
+}
+
+// NewResult converts a single row into the variant selected by its wrapperType.
+// Rows without a recognised wrapperType (such as ebooks, which omit it) are
+// treated as tracks, or as software when they carry a bundle ID.
+func NewResult(r ContentResult) Result {
+	switch r.WrapperType {
+	case WrapperTypeArtist:
+		return &Artist{
+			ArtistID:       r.ArtistID,
+			AMGArtistID:    r.AMGArtistID,
+			ArtistName:     r.ArtistName,
+			ArtistType:     r.ArtistType,
+			ArtistLinkURL:  r.ArtistLinkURL,
+			PrimaryGenre:   r.PrimaryGenre,
+			PrimaryGenreID: r.PrimaryGenreID,
+		}
+	case WrapperTypeCollection:
+		return &Collection{
+			CollectionID:           r.CollectionID,
+			CollectionName:         r.CollectionName,
+			CollectionType:         r.CollectionType,
+			CollectionViewURL:      r.CollectionViewURL,
+			ArtistID:               r.ArtistID,
+			AMGArtistID:            r.AMGArtistID,
+			ArtistName:             r.ArtistName,
+			ArtworkURL:             r.ArtworkURL100,
+			CollectionPrice:        r.CollectionPrice,
+			Currency:               r.Currency,
+			CollectionExplicitness: r.CollectionExplicitness,
+			TrackCount:             r.TrackCount,
+			Copyright:              r.Copyright,
+			Country:                r.Country,
+			ReleaseDate:            r.ReleaseDate,
+			PrimaryGenre:           r.PrimaryGenre,
+		}
+	case WrapperTypeAudiobook:
+		return &Audiobook{
+			CollectionID:           r.CollectionID,
+			CollectionName:         r.CollectionName,
+			CollectionViewURL:      r.CollectionViewURL,
+			ArtistID:               r.ArtistID,
+			ArtistName:             r.ArtistName,
+			ArtworkURL:             r.ArtworkURL100,
+			CollectionPrice:        r.CollectionPrice,
+			Currency:               r.Currency,
+			CollectionExplicitness: r.CollectionExplicitness,
+			TrackCount:             r.TrackCount,
+			Description:            r.Description,
+			PreviewURL:             r.PreviewURL,
+			ReleaseDate:            r.ReleaseDate,
+			PrimaryGenre:           r.PrimaryGenre,
+		}
+	case WrapperTypePodcastEpisode:
+		return &PodcastEpisode{
+			TrackID:          r.TrackID,
+			TrackName:        r.TrackName,
+			TrackViewURL:     r.TrackViewURL,
+			CollectionID:     r.CollectionID,
+			CollectionName:   r.CollectionName,
+			FeedURL:          r.FeedURL,
+			EpisodeURL:       r.EpisodeURL,
+			EpisodeGUID:      r.EpisodeGUID,
+			TrackTimeMillis:  r.TrackTimeMillis,
+			ArtworkURL:       r.ArtworkURL600,
+			ShortDescription: r.ShortDescription,
+			ReleaseDate:      r.ReleaseDate,
+		}
+	}
+
+	if r.WrapperType == WrapperTypeSoftware || (r.WrapperType == "" && r.BundleID != "") {
+		return &Software{
+			TrackID:                   r.TrackID,
+			TrackName:                 r.TrackName,
+			TrackViewURL:              r.TrackViewURL,
+			BundleID:                  r.BundleID,
+			SellerName:                r.SellerName,
+			Version:                   r.Version,
+			MinimumOSVersion:          r.MinimumOSVersion,
+			FileSizeBytes:             r.FileSizeBytes,
+			Price:                     r.Price,
+			FormattedPrice:            r.FormattedPrice,
+			Currency:                  r.Currency,
+			ReleaseDate:               r.ReleaseDate,
+			CurrentVersionReleaseDate: r.CurrentVersionReleaseDate,
+			PrimaryGenre:              r.PrimaryGenre,
+			AverageRating:             r.AverageRating,
+			RatingCount:               r.RatingCount,
+		}
+	}
+
+	return &Track{
+		Kind:              r.Kind,
+		TrackID:           r.TrackID,
+		TrackName:         r.TrackName,
+		TrackViewURL:      r.TrackViewURL,
+		ArtistID:          r.ArtistID,
+		ArtistName:        r.ArtistName,
+		CollectionID:      r.CollectionID,
+		CollectionName:    r.CollectionName,
+		TrackNumber:       r.TrackNumber,
+		TrackCount:        r.TrackCount,
+		DiscNumber:        r.DiscNumber,
+		DiscCount:         r.DiscCount,
+		TrackTimeMillis:   r.TrackTimeMillis,
+		PreviewURL:        r.PreviewURL,
+		ArtworkURL:        r.ArtworkURL100,
+		TrackPrice:        r.TrackPrice,
+		Currency:          r.Currency,
+		TrackExplicitness: r.TrackExplicitness,
+		ReleaseDate:       r.ReleaseDate,
+		PrimaryGenre:      r.PrimaryGenre,
+	}
+}
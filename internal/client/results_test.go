@@ -0,0 +1,100 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTypedResults_MixedArtistAndCollections(t *testing.T) {
+	body := `{"results":[
+		{"wrapperType":"artist","artistType":"Artist","artistId":909253,"amgArtistId":468749,"artistName":"Jack Johnson"},
+		{"wrapperType":"collection","collectionType":"Album","collectionId":1469577723,"artistId":909253,"collectionName":"Jack Johnson and Friends","trackCount":14},
+		{"wrapperType":"collection","collectionType":"Album","collectionId":1440857781,"artistId":909253,"collectionName":"In Between Dreams","trackCount":14}
+	]}`
+
+	var resp ContentResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	typed := resp.Typed()
+	if len(typed) != 3 {
+		t.Fatalf("expected 3 typed results, got %d", len(typed))
+	}
+
+	artist, ok := typed[0].(*Artist)
+	if !ok {
+		t.Fatalf("expected *Artist, got %T", typed[0])
+	}
+	if artist.ID() != 909253 || artist.AMGArtistID != 468749 || artist.Name() != "Jack Johnson" {
+		t.Errorf("unexpected artist: %+v", artist)
+	}
+
+	for i, r := range typed[1:] {
+		collection, ok := r.(*Collection)
+		if !ok {
+			t.Fatalf("expected *Collection at %d, got %T", i+1, r)
+		}
+		if collection.WrapperType() != WrapperTypeCollection || collection.CollectionType != "Album" {
+			t.Errorf("unexpected collection: %+v", collection)
+		}
+	}
+	if typed[2].Name() != "In Between Dreams" {
+		t.Errorf("expected order to be preserved, got %q", typed[2].Name())
+	}
+}
+
+func TestNewResult_Variants(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   ContentResult
+		expected string
+		id       int64
+	}{
+		{
+			name:     "track",
+			result:   ContentResult{WrapperType: "track", Kind: "song", TrackID: 1, TrackName: "Song"},
+			expected: WrapperTypeTrack,
+			id:       1,
+		},
+		{
+			name:     "software",
+			result:   ContentResult{WrapperType: "software", TrackID: 2, BundleID: "com.example.app"},
+			expected: WrapperTypeSoftware,
+			id:       2,
+		},
+		{
+			name:     "audiobook",
+			result:   ContentResult{WrapperType: "audiobook", CollectionID: 3, CollectionName: "Book"},
+			expected: WrapperTypeAudiobook,
+			id:       3,
+		},
+		{
+			name:     "podcast episode",
+			result:   ContentResult{WrapperType: "podcastEpisode", TrackID: 4, EpisodeURL: "https://example.com/ep.mp3"},
+			expected: WrapperTypePodcastEpisode,
+			id:       4,
+		},
+		{
+			name:     "ebook without wrapper type",
+			result:   ContentResult{Kind: "ebook", TrackID: 5},
+			expected: WrapperTypeTrack,
+			id:       5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResult(tt.result)
+			if r.WrapperType() != tt.expected {
+				t.Errorf("expected wrapper type %q, got %q", tt.expected, r.WrapperType())
+			}
+			if r.ID() != tt.id {
+				t.Errorf("expected ID %d, got %d", tt.id, r.ID())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+// retryAfterMargin is added to waits requested by Retry-After, which has
+// one-second resolution, so the retry does not arrive a moment too early.
+const retryAfterMargin = 1 * time.Second
+
+// parseRetryAfter parses a Retry-After header value, which RFC 7231 allows to
+// be either a number of seconds or an HTTP-date. Dates in the past yield zero.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// fullJitter returns a random duration between zero and d, inclusive.
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
+
+// backoff returns the wait before the given retry (starting at 1): a random
+// duration up to an exponentially growing ceiling capped at maxRetryWait, so
+// that parallel clients do not retry in lockstep.
+func (c *Client) backoff(retryCount int) time.Duration {
+	ceiling := c.maxRetryWait
+	if shift := retryCount - 1; shift < 32 {
+		ceiling = min(common.RetryBaseDelay<<shift, c.maxRetryWait)
+	}
+	return c.jitter(ceiling)
+}
+
+// retryDelay returns the wait before retrying a response, honouring a valid
+// Retry-After header and otherwise backing off. The source reports which was
+// used, for logging.
+func (c *Client) retryDelay(resp *http.Response, retryCount int) (wait time.Duration, source string) {
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return min(retryAfter+retryAfterMargin, c.maxRetryWait), "retry-after"
+	}
+	return c.backoff(retryCount), "backoff"
+}
+
+// isTransientNetworkError reports whether a failed request is worth retrying:
+// timeouts, connection resets and refusals, and connections closed before a
+// response was received.
+func isTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
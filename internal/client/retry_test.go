@@ -0,0 +1,186 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "padded", value: " 3 ", want: 3 * time.Second, wantOK: true},
+		{name: "http date", value: "Sun, 01 Mar 2026 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "rfc 850 date", value: "Sunday, 01-Mar-26 12:01:00 GMT", want: time.Minute, wantOK: true},
+		{name: "asctime date", value: "Sun Mar  1 12:00:05 2026", want: 5 * time.Second, wantOK: true},
+		{name: "date in the past", value: "Sun, 01 Mar 2026 11:00:00 GMT", want: 0, wantOK: true},
+		{name: "empty", value: "", wantOK: false},
+		{name: "negative", value: "-5", wantOK: false},
+		{name: "fractional", value: "1.5", wantOK: false},
+		{name: "garbage", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFullJitter(t *testing.T) {
+	if got := fullJitter(0); got != 0 {
+		t.Errorf("expected 0 for a zero ceiling, got %s", got)
+	}
+	for range 1000 {
+		if got := fullJitter(time.Second); got < 0 || got > time.Second {
+			t.Fatalf("jitter %s outside [0, 1s]", got)
+		}
+	}
+}
+
+func TestBackoff_CeilingGrowsAndCaps(t *testing.T) {
+	c := NewClientWithConfig(Config{MaxRetryWait: 5 * time.Second})
+	c.jitter = func(d time.Duration) time.Duration { return d }
+
+	want := []time.Duration{
+		common.RetryBaseDelay,
+		2 * common.RetryBaseDelay,
+		4 * common.RetryBaseDelay,
+		5 * time.Second,
+		5 * time.Second,
+	}
+	for i, w := range want {
+		if got := c.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+	if got := c.backoff(100); got != 5*time.Second {
+		t.Errorf("backoff(100) = %s, want the 5s cap", got)
+	}
+}
+
+func TestDoRequest_429WithHTTPDateRetryAfter(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.Header().Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.jitter = func(time.Duration) time.Duration {
+		t.Error("did not expect backoff when Retry-After is a valid date")
+		return 0
+	}
+	resp, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 calls, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestDoRequest_429InvalidRetryAfterBacksOff(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) <= 2 {
+			w.Header().Set("Retry-After", "whenever")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	var ceilings []time.Duration
+	c := newTestClient(server.URL)
+	c.jitter = func(d time.Duration) time.Duration {
+		ceilings = append(ceilings, d)
+		return 0
+	}
+	resp, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if atomic.LoadInt32(&callCount) != 3 {
+		t.Errorf("expected 3 calls, got %d", atomic.LoadInt32(&callCount))
+	}
+	if len(ceilings) != 2 || ceilings[0] != common.RetryBaseDelay || ceilings[1] != 2*common.RetryBaseDelay {
+		t.Errorf("unexpected backoff ceilings %v", ceilings)
+	}
+}
+
+func TestDoRequest_RetriesDroppedConnection(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack failed: %v", err)
+				return
+			}
+			_ = conn.Close()
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"results":[]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.jitter = func(time.Duration) time.Duration { return 0 }
+	resp, err := c.doRequest(context.Background(), server.URL+"/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 calls, got %d", atomic.LoadInt32(&callCount))
+	}
+}
+
+func TestDoRequest_NetworkErrorExceedsMaxRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	c := NewClientWithConfig(Config{BaseURL: serverURL + "/", MaxRetries: 3})
+	c.jitter = func(time.Duration) time.Duration { return 0 }
+	_, err := c.doRequest(context.Background(), serverURL+"/test")
+	if err == nil {
+		t.Fatal("expected error for a refused connection")
+	}
+	if want := "after 3 attempts"; !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error to mention %q, got %v", want, err)
+	}
+}
@@ -4,12 +4,13 @@
 package client
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"iter"
 	"net/url"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
 )
 
 // Search performs a search against the iTunes Search API with the provided parameters.
@@ -49,33 +50,85 @@ func (c *Client) Search(ctx context.Context, req SearchRequest) (*ContentRespons
 
 	apiURL := fmt.Sprintf("%s/search?%s", c.baseURL, query.Encode())
 
-	resp, err := c.doRequest(ctx, apiURL)
+	body, err := c.getBody(ctx, OperationSearch, apiURL)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			fmt.Printf("warning: failed to close response body: %v\n", err)
-		}
-	}()
 
-	var decoder io.Reader = resp.Body
 	if req.Callback != "" {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("error reading callback response: %w", err)
-		}
-		payload, err := unwrapJSONPBody(bodyBytes, req.Callback)
+		body, err = unwrapJSONPBody(body, req.Callback)
 		if err != nil {
 			return nil, err
 		}
-		decoder = bytes.NewReader(payload)
 	}
 
 	var result ContentResponse
-	if err := json.NewDecoder(decoder).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("error decoding API response: %w", err)
 	}
+	result.tagStorefront(c.country(req.Country))
 
 	return &result, nil
 }
+
+// SearchAll returns an iterator over search results that follows offset
+// pagination until the API runs out of results or maxResults results have been
+// yielded. A maxResults of zero or less means no cap. Each page is fetched with
+// Search, so every request draws from the client's rate limiter. Results are
+// de-duplicated by track ID across pages; rows without a track ID are always
+// yielded. Iteration stops after the first error.
+func (c *Client) SearchAll(ctx context.Context, req SearchRequest, maxResults int64) iter.Seq2[ContentResult, error] {
+	return func(yield func(ContentResult, error) bool) {
+		pageSize := req.Limit
+		if pageSize <= 0 || pageSize > common.MaxSearchPageSize {
+			pageSize = common.MaxSearchPageSize
+		}
+
+		var offset int64
+		if req.Offset != nil {
+			offset = *req.Offset
+		}
+
+		seen := make(map[int64]bool)
+		var yielded int64
+
+		for {
+			pageReq := req
+			pageReq.Limit = pageSize
+			pageOffset := offset
+			pageReq.Offset = &pageOffset
+
+			page, err := c.Search(ctx, pageReq)
+			if err != nil {
+				yield(ContentResult{}, err)
+				return
+			}
+
+			newResults := 0
+			for _, result := range page.Results {
+				if result.TrackID != 0 {
+					if seen[result.TrackID] {
+						continue
+					}
+					seen[result.TrackID] = true
+				}
+				newResults++
+
+				if !yield(result, nil) {
+					return
+				}
+				yielded++
+				if maxResults > 0 && yielded >= maxResults {
+					return
+				}
+			}
+
+			// A short page means the API has no more results; a page of nothing
+			// but duplicates means the API is ignoring the offset.
+			if int64(len(page.Results)) < pageSize || newResults == 0 {
+				return
+			}
+			offset += int64(len(page.Results))
+		}
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
 )
 
 func TestSearch_Success(t *testing.T) {
@@ -128,3 +131,148 @@ func TestSearch_ExplicitParameter(t *testing.T) {
 		t.Errorf("expected explicit %q, got %q", "No", receivedExplicit)
 	}
 }
+
+// newPaginatedServer returns a server that serves total sequential track IDs,
+// honouring the limit and offset query parameters.
+func newPaginatedServer(t *testing.T, total int, requests *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*requests = append(*requests, q.Get("offset"))
+
+		var limit, offset int
+		_, _ = fmt.Sscan(q.Get("limit"), &limit)
+		_, _ = fmt.Sscan(q.Get("offset"), &offset)
+
+		var rows []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			rows = append(rows, fmt.Sprintf(`{"trackId":%d}`, i+1))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"results":[%s]}`, strings.Join(rows, ","))
+	}))
+}
+
+func TestSearchAll_FollowsPagesUntilExhausted(t *testing.T) {
+	var requests []string
+	server := newPaginatedServer(t, 25, &requests)
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	var ids []int64
+	for result, err := range c.SearchAll(context.Background(), SearchRequest{Term: "test", Limit: 10}, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, result.TrackID)
+	}
+
+	if len(ids) != 25 {
+		t.Fatalf("expected 25 results, got %d", len(ids))
+	}
+	if ids[0] != 1 || ids[24] != 25 {
+		t.Errorf("expected results in order, got first %d last %d", ids[0], ids[24])
+	}
+	if strings.Join(requests, ",") != "0,10,20" {
+		t.Errorf("expected offsets 0,10,20, got %v", requests)
+	}
+}
+
+func TestSearchAll_StopsAtMaxResults(t *testing.T) {
+	var requests []string
+	server := newPaginatedServer(t, 100, &requests)
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	count := 0
+	for _, err := range c.SearchAll(context.Background(), SearchRequest{Term: "test", Limit: 10}, 15) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 15 {
+		t.Errorf("expected 15 results, got %d", count)
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestSearchAll_DeduplicatesAndStopsWhenOffsetIgnored(t *testing.T) {
+	var callCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, `{"results":[{"trackId":1},{"trackId":2}]}`)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	count := 0
+	for _, err := range c.SearchAll(context.Background(), SearchRequest{Term: "test", Limit: 2}, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 unique results, got %d", count)
+	}
+	if callCount != 2 {
+		t.Errorf("expected 2 requests, got %d", callCount)
+	}
+}
+
+func TestSearchAll_PropagatesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	var gotErr error
+	for _, err := range c.SearchAll(context.Background(), SearchRequest{Term: "test"}, 0) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Fatal("expected error from SearchAll")
+	}
+}
+
+func TestSearch_FakeServerJSONP(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := newTestClient(server.URL)
+
+	result, err := c.Search(context.Background(), SearchRequest{Term: "pages", Media: "software", Callback: "handle"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 1 || result.Results[0].BundleID != "com.apple.Pages" {
+		t.Errorf("unexpected results: %+v", result.Results)
+	}
+}
+
+func TestSearchAll_FakeServerRecoversFromServerError(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	server.FailWithStatus(1, http.StatusServiceUnavailable)
+	c := newTestClient(server.URL)
+
+	var names []string
+	for r, err := range c.SearchAll(context.Background(), SearchRequest{Term: "jack johnson", Limit: 2}, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, r.TrackName+r.CollectionName+r.ArtistName)
+	}
+	if len(names) != 5 {
+		t.Errorf("expected 5 results, got %d: %v", len(names), names)
+	}
+	if server.RequestCount() != 4 {
+		t.Errorf("expected 4 requests (1 failed, 3 pages), got %d", server.RequestCount())
+	}
+}
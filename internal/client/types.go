@@ -5,40 +5,118 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
 )
 
 // Logger defines the interface for logging HTTP requests and responses.
 type Logger interface {
 	LogRequest(ctx context.Context, method, url string, body []byte)
 	LogResponse(ctx context.Context, statusCode int, headers http.Header, body []byte)
-	LogAuth(ctx context.Context, message string, fields map[string]any)
+	// LogEvent logs client events such as retries, cache hits, and rate limit
+	// changes.
+	LogEvent(ctx context.Context, message string, fields map[string]any)
 }
 
-// APIError represents an error response from the iTunes Search API.
+// Sentinel errors returned, possibly wrapped, by requests to the API.
+var (
+	// ErrRateLimited is matched by the *APIError returned when the API keeps
+	// responding with HTTP 429 after the configured retries.
+	ErrRateLimited = errors.New("rate limit retries exhausted")
+	// ErrCanceled is returned when the context is canceled or times out while
+	// a request is waiting for the rate limiter, in flight, or backing off.
+	ErrCanceled = errors.New("request canceled")
+)
+
+// APIError represents an unsuccessful response from the iTunes Search API or
+// artwork CDN, after any retries.
 type APIError struct {
+	// StatusCode is the HTTP status of the final response.
 	StatusCode int
-	Message    string
+	// Body is the start of the final response body, for diagnostics.
+	Body string
+	// URL is the requested URL.
+	URL string
+	// Attempts is the number of requests made, including retries.
+	Attempts int
+	// RetryAfter is the wait requested by the final response's Retry-After
+	// header, or zero when it had none.
+	RetryAfter time.Duration
+	// Err is ErrRateLimited when HTTP 429 responses exhausted the retries.
+	Err error
 }
 
 // Error implements the error interface for APIError.
 func (e *APIError) Error() string {
-	return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, e.Message)
+	var b strings.Builder
+	fmt.Fprintf(&b, "API request to %s failed with HTTP %d", e.URL, e.StatusCode)
+	if e.Attempts > 1 {
+		fmt.Fprintf(&b, " after %d attempts", e.Attempts)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, " (%s)", e.Err)
+	}
+	if e.Body != "" {
+		fmt.Fprintf(&b, ": %s", e.Body)
+	}
+	return b.String()
+}
+
+// Unwrap returns the sentinel error, if any, so errors.Is(err, ErrRateLimited) works.
+func (e *APIError) Unwrap() error {
+	return e.Err
 }
 
-// NotFoundError represents an error when requested IDs or URLs are not found.
+// canceledError wraps the context's error with ErrCanceled.
+func canceledError(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrCanceled, context.Cause(ctx))
+}
+
+// NotFoundError represents an error when requested IDs, URLs, or other lookup
+// selector values are not found.
 type NotFoundError struct {
-	MissingIDs  []int64
-	MissingURLs []string
+	MissingIDs       []int64
+	MissingURLs      []string
+	MissingBundleIDs []string
+	MissingUPCs      []string
+	MissingISBNs     []string
+	MissingAMGIDs    []int64
 }
 
 // Error implements the error interface for NotFoundError.
 func (e *NotFoundError) Error() string {
+	var parts []string
 	if len(e.MissingIDs) > 0 {
-		return fmt.Sprintf("The following IDs were not found: %v", e.MissingIDs)
+		parts = append(parts, fmt.Sprintf("The following IDs were not found: %v", e.MissingIDs))
+	}
+	if len(e.MissingURLs) > 0 {
+		parts = append(parts, fmt.Sprintf("The following URLs were not found: %v", e.MissingURLs))
+	}
+	if len(e.MissingBundleIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("The following bundle IDs were not found: %v", e.MissingBundleIDs))
+	}
+	if len(e.MissingUPCs) > 0 {
+		parts = append(parts, fmt.Sprintf("The following UPCs were not found: %v", e.MissingUPCs))
+	}
+	if len(e.MissingISBNs) > 0 {
+		parts = append(parts, fmt.Sprintf("The following ISBNs were not found: %v", e.MissingISBNs))
+	}
+	if len(e.MissingAMGIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("The following AMG IDs were not found: %v", e.MissingAMGIDs))
 	}
-	return fmt.Sprintf("The following URLs were not found: %v", e.MissingURLs)
+	return strings.Join(parts, "; ")
+}
+
+// empty reports whether no missing values were recorded.
+func (e *NotFoundError) empty() bool {
+	return len(e.MissingIDs) == 0 && len(e.MissingURLs) == 0 && len(e.MissingBundleIDs) == 0 &&
+		len(e.MissingUPCs) == 0 && len(e.MissingISBNs) == 0 && len(e.MissingAMGIDs) == 0
 }
 
 // ContentResponse represents the response envelope from the iTunes Search API.
@@ -46,30 +124,142 @@ type ContentResponse struct {
 	Results []ContentResult `json:"results"`
 }
 
+// tagStorefront records the queried storefront on every result, defaulting to
+// the storefront the API uses when no country is sent.
+func (r *ContentResponse) tagStorefront(country string) {
+	if country == "" {
+		country = common.DefaultCountry
+	}
+	for i := range r.Results {
+		r.Results[i].Storefront = strings.ToLower(country)
+	}
+}
+
 // ContentResult represents a single content item returned by the iTunes Search API.
 type ContentResult struct {
-	TrackName        string   `json:"trackName"`
-	BundleID         string   `json:"bundleId"`
-	TrackID          int64    `json:"trackId"`
-	SellerName       string   `json:"sellerName"`
-	Kind             string   `json:"kind"`
-	Description      string   `json:"description"`
-	ReleaseDate      string   `json:"releaseDate"`
-	Price            float64  `json:"price"`
-	FormattedPrice   string   `json:"formattedPrice"`
-	Currency         string   `json:"currency"`
-	Version          string   `json:"version"`
-	PrimaryGenre     string   `json:"primaryGenreName"`
-	MinimumOSVersion string   `json:"minimumOsVersion"`
-	FileSizeBytes    string   `json:"fileSizeBytes"`
-	ArtistViewURL    string   `json:"artistViewUrl"`
-	ArtworkURL       string   `json:"artworkUrl512"`
-	TrackViewURL     string   `json:"trackViewUrl"`
-	SupportedDevices []string `json:"supportedDevices"`
-	Genres           []string `json:"genres"`
-	Languages        []string `json:"languageCodesISO2A"`
-	AverageRating    float64  `json:"averageUserRating"`
-	RatingCount      int64    `json:"userRatingCount"`
+	TrackName                   string    `json:"trackName"`
+	BundleID                    string    `json:"bundleId"`
+	TrackID                     int64     `json:"trackId"`
+	SellerName                  string    `json:"sellerName"`
+	Kind                        string    `json:"kind"`
+	Description                 string    `json:"description"`
+	ReleaseDate                 string    `json:"releaseDate"`
+	Price                       float64   `json:"price"`
+	FormattedPrice              string    `json:"formattedPrice"`
+	Currency                    string    `json:"currency"`
+	Version                     string    `json:"version"`
+	PrimaryGenre                string    `json:"primaryGenreName"`
+	MinimumOSVersion            string    `json:"minimumOsVersion"`
+	FileSizeBytes               string    `json:"fileSizeBytes"`
+	ArtistViewURL               string    `json:"artistViewUrl"`
+	ArtworkURL                  string    `json:"artworkUrl512"`
+	TrackViewURL                string    `json:"trackViewUrl"`
+	SupportedDevices            []string  `json:"supportedDevices"`
+	Genres                      GenreList `json:"genres"`
+	Languages                   []string  `json:"languageCodesISO2A"`
+	AverageRating               float64   `json:"averageUserRating"`
+	RatingCount                 int64     `json:"userRatingCount"`
+	WrapperType                 string    `json:"wrapperType"`
+	ArtistID                    int64     `json:"artistId"`
+	ArtistIDs                   []int64   `json:"artistIds"`
+	AMGArtistID                 int64     `json:"amgArtistId"`
+	ArtistName                  string    `json:"artistName"`
+	ArtistType                  string    `json:"artistType"`
+	ArtistLinkURL               string    `json:"artistLinkUrl"`
+	CollectionID                int64     `json:"collectionId"`
+	CollectionName              string    `json:"collectionName"`
+	CollectionCensoredName      string    `json:"collectionCensoredName"`
+	CollectionArtistID          int64     `json:"collectionArtistId"`
+	CollectionArtistName        string    `json:"collectionArtistName"`
+	CollectionViewURL           string    `json:"collectionViewUrl"`
+	CollectionType              string    `json:"collectionType"`
+	TrackCensoredName           string    `json:"trackCensoredName"`
+	PreviewURL                  string    `json:"previewUrl"`
+	ArtworkURL30                string    `json:"artworkUrl30"`
+	ArtworkURL60                string    `json:"artworkUrl60"`
+	ArtworkURL100               string    `json:"artworkUrl100"`
+	ArtworkURL600               string    `json:"artworkUrl600"`
+	CollectionPrice             float64   `json:"collectionPrice"`
+	CollectionHDPrice           float64   `json:"collectionHdPrice"`
+	TrackPrice                  float64   `json:"trackPrice"`
+	TrackHDPrice                float64   `json:"trackHdPrice"`
+	TrackRentalPrice            float64   `json:"trackRentalPrice"`
+	TrackHDRentalPrice          float64   `json:"trackHdRentalPrice"`
+	Country                     string    `json:"country"`
+	CollectionExplicitness      string    `json:"collectionExplicitness"`
+	TrackExplicitness           string    `json:"trackExplicitness"`
+	ContentAdvisoryRating       string    `json:"contentAdvisoryRating"`
+	TrackContentRating          string    `json:"trackContentRating"`
+	Advisories                  []string  `json:"advisories"`
+	DiscCount                   int64     `json:"discCount"`
+	DiscNumber                  int64     `json:"discNumber"`
+	TrackCount                  int64     `json:"trackCount"`
+	TrackNumber                 int64     `json:"trackNumber"`
+	TrackTimeMillis             int64     `json:"trackTimeMillis"`
+	PrimaryGenreID              int64     `json:"primaryGenreId"`
+	GenreIDs                    []string  `json:"genreIds"`
+	ShortDescription            string    `json:"shortDescription"`
+	LongDescription             string    `json:"longDescription"`
+	Copyright                   string    `json:"copyright"`
+	IsStreamable                bool      `json:"isStreamable"`
+	HasITunesExtras             bool      `json:"hasITunesExtras"`
+	FeedURL                     string    `json:"feedUrl"`
+	EpisodeURL                  string    `json:"episodeUrl"`
+	EpisodeGUID                 string    `json:"episodeGuid"`
+	EpisodeFileExtension        string    `json:"episodeFileExtension"`
+	EpisodeContentType          string    `json:"episodeContentType"`
+	SellerURL                   string    `json:"sellerUrl"`
+	ReleaseNotes                string    `json:"releaseNotes"`
+	CurrentVersionReleaseDate   string    `json:"currentVersionReleaseDate"`
+	ScreenshotURLs              []string  `json:"screenshotUrls"`
+	IPadScreenshotURLs          []string  `json:"ipadScreenshotUrls"`
+	AppleTVScreenshotURLs       []string  `json:"appletvScreenshotUrls"`
+	Features                    []string  `json:"features"`
+	IsGameCenterEnabled         bool      `json:"isGameCenterEnabled"`
+	AverageRatingCurrentVersion float64   `json:"averageUserRatingForCurrentVersion"`
+	RatingCountCurrentVersion   int64     `json:"userRatingCountForCurrentVersion"`
+
+	// UPC, ISBN, AMGAlbumID, and AMGVideoID are only set on rows that echo
+	// the identifier they were looked up by; most rows omit them.
+	UPC        string `json:"upc"`
+	ISBN       string `json:"isbn"`
+	AMGAlbumID int64  `json:"amgAlbumId"`
+	AMGVideoID int64  `json:"amgVideoId"`
+
+	// Storefront is the lowercase country code of the storefront that was
+	// queried. It is set by the client rather than decoded from the response.
+	Storefront string `json:"-"`
+}
+
+// GenreList holds genre names. Podcast episode results return genres as
+// objects rather than strings, so both shapes are accepted when decoding.
+type GenreList []string
+
+// UnmarshalJSON implements json.Unmarshaler for GenreList.
+func (g *GenreList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	genres := make(GenreList, 0, len(raw))
+	for _, item := range raw {
+		var name string
+		if err := json.Unmarshal(item, &name); err == nil {
+			genres = append(genres, name)
+			continue
+		}
+		var obj struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return fmt.Errorf("unexpected genre value %s: %w", item, err)
+		}
+		genres = append(genres, obj.Name)
+	}
+
+	*g = genres
+	return nil
 }
 
 // LookupRequest captures the supported query parameters for lookup operations.
@@ -81,6 +271,7 @@ type LookupRequest struct {
 	UPCs         []string
 	ISBNs        []string
 	BundleIDs    []string
+	Media        string
 	Entity       string
 	Country      string
 	Limit        int64
@@ -0,0 +1,218 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CountryCodeRegex matches a lowercase ISO 3166-1 alpha-2 country code.
+var CountryCodeRegex = regexp.MustCompile(`^[a-z]{2}$`)
+
+// appleIDRegex matches the trailing ID segment of an Apple media URL, which is
+// id{digits} on most hosts and plain digits on music.apple.com.
+var appleIDRegex = regexp.MustCompile(`^(?:id)?(\d+)$`)
+
+// Content types that appear in the path of Apple media URLs.
+const (
+	AppleURLKindApp        = "app"
+	AppleURLKindDeveloper  = "developer"
+	AppleURLKindAlbum      = "album"
+	AppleURLKindSong       = "song"
+	AppleURLKindArtist     = "artist"
+	AppleURLKindMusicVideo = "music-video"
+	AppleURLKindPodcast    = "podcast"
+	AppleURLKindBook       = "book"
+	AppleURLKindAudiobook  = "audiobook"
+	AppleURLKindAuthor     = "author"
+	AppleURLKindMovie      = "movie"
+	AppleURLKindTVSeason   = "tv-season"
+	AppleURLKindSeason     = "season"
+	AppleURLKindShow       = "show"
+	AppleURLKindEpisode    = "episode"
+)
+
+// appleURLHosts lists the hosts of Apple media URLs. A leading "geo." is
+// stripped before matching.
+var appleURLHosts = []string{
+	"apps.apple.com",
+	"itunes.apple.com",
+	"music.apple.com",
+	"podcasts.apple.com",
+	"books.apple.com",
+	"tv.apple.com",
+}
+
+// appleURLMediaEntity is the iTunes Search API media type and entity of an
+// Apple media URL.
+type appleURLMediaEntity struct{ media, entity string }
+
+// appleURLMedia maps the content type in a URL path to the iTunes Search API
+// media and entity it corresponds to.
+var appleURLMedia = map[string]appleURLMediaEntity{
+	AppleURLKindApp:        {"software", "software"},
+	AppleURLKindDeveloper:  {"software", "softwareDeveloper"},
+	AppleURLKindAlbum:      {"music", "album"},
+	AppleURLKindSong:       {"music", "song"},
+	AppleURLKindArtist:     {"music", "musicArtist"},
+	AppleURLKindMusicVideo: {"musicVideo", "musicVideo"},
+	AppleURLKindPodcast:    {"podcast", "podcast"},
+	AppleURLKindBook:       {"ebook", "ebook"},
+	AppleURLKindAudiobook:  {"audiobook", "audiobook"},
+	AppleURLKindAuthor:     {"ebook", "allArtist"},
+	AppleURLKindMovie:      {"movie", "movie"},
+	AppleURLKindTVSeason:   {"tvShow", "tvSeason"},
+	AppleURLKindSeason:     {"tvShow", "tvSeason"},
+	AppleURLKindShow:       {"tvShow", "tvSeason"},
+	AppleURLKindEpisode:    {"tvShow", "tvEpisode"},
+}
+
+// appleURLHostKinds maps hosts that serve a single kind of content to the
+// content type assumed when the path has no content type segment, as in
+// https://books.apple.com/us/id1435728082.
+var appleURLHostKinds = map[string]string{
+	"apps.apple.com":     AppleURLKindApp,
+	"books.apple.com":    AppleURLKindBook,
+	"podcasts.apple.com": AppleURLKindPodcast,
+}
+
+// appleURLHostMedia overrides appleURLMedia on hosts where a content type
+// shared with other hosts refers to that host's content, such as an artist
+// on Apple Podcasts being a podcast author rather than a musician.
+var appleURLHostMedia = map[string]map[string]appleURLMediaEntity{
+	"books.apple.com": {
+		AppleURLKindArtist: {"ebook", "allArtist"},
+	},
+	"podcasts.apple.com": {
+		AppleURLKindArtist:  {"podcast", "podcastAuthor"},
+		AppleURLKindEpisode: {"podcast", "podcastEpisode"},
+	},
+}
+
+// AppleURL holds the components of an Apple media URL.
+type AppleURL struct {
+	// Country is the storefront named in the URL, or the default country when
+	// the URL omits it.
+	Country string
+	// Kind is the content type from the URL path, such as "app" or "album".
+	Kind string
+	// Slug is the URL name of the item. It is empty when the URL omits it.
+	Slug string
+	// ID is the iTunes ID of the item. For album and podcast URLs that select
+	// a single track or episode with the i query parameter, it is that
+	// track's ID.
+	ID int64
+	// Media and Entity are the iTunes Search API media type and entity of the
+	// item, such as "software" and "macSoftware".
+	Media  string
+	Entity string
+}
+
+// IsApp reports whether the URL links to an iOS or Mac App Store app.
+func (u AppleURL) IsApp() bool {
+	return u.Kind == AppleURLKindApp
+}
+
+// ParseAppleURL parses an Apple media URL from any of the App Store, iTunes,
+// Apple Music, Apple Podcasts, Apple Books, or Apple TV hosts, for example:
+//
+//	https://apps.apple.com/gb/app/pages/id361309726
+//	https://itunes.apple.com/us/app/xcode/id497799835?mt=12
+//	https://apps.apple.com/app/id361309726
+//	https://music.apple.com/us/album/in-between-dreams/1469577723?i=1469577741
+//
+// Query parameters other than mt and i, such as l, are ignored. When the URL
+// does not name a storefront, defaultCountry is used. The media and entity
+// are inferred from the content type in the path as interpreted on the host,
+// and on single-purpose hosts such as books.apple.com the content type may be
+// omitted.
+func ParseAppleURL(rawURL, defaultCountry string) (AppleURL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: expected an http:// or https:// link", rawURL)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "geo.")
+	if !slices.Contains(appleURLHosts, host) {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: host must be one of %s", rawURL, strings.Join(appleURLHosts, ", "))
+	}
+
+	var segments []string
+	for segment := range strings.SplitSeq(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	parsed := AppleURL{Country: defaultCountry}
+	if len(segments) > 0 && CountryCodeRegex.MatchString(strings.ToLower(segments[0])) {
+		parsed.Country = strings.ToLower(segments[0])
+		segments = segments[1:]
+	}
+
+	if kind, ok := appleURLHostKinds[host]; ok && len(segments) == 1 {
+		segments = []string{kind, segments[0]}
+	}
+	if len(segments) < 2 || len(segments) > 3 {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: expected a path such as /{country}/app/{name}/id{id}", rawURL)
+	}
+
+	parsed.Kind = strings.ToLower(segments[0])
+	media, ok := appleURLHostMedia[host][parsed.Kind]
+	if !ok {
+		media, ok = appleURLMedia[parsed.Kind]
+	}
+	if !ok {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: unsupported content type %q", rawURL, segments[0])
+	}
+	parsed.Media, parsed.Entity = media.media, media.entity
+
+	idSegment := segments[len(segments)-1]
+	matches := appleIDRegex.FindStringSubmatch(idSegment)
+	if matches == nil {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: %q is not a numeric iTunes ID; Apple TV catalog IDs such as umc.cmc.* cannot be looked up with the iTunes Search API", rawURL, idSegment)
+	}
+	if parsed.ID, err = strconv.ParseInt(matches[1], 10, 64); err != nil {
+		return AppleURL{}, fmt.Errorf("invalid Apple URL %q: %w", rawURL, err)
+	}
+	if len(segments) == 3 {
+		parsed.Slug = segments[1]
+	}
+
+	query := u.Query()
+	if parsed.Kind == AppleURLKindApp && query.Get("mt") == "12" {
+		parsed.Entity = "macSoftware"
+	}
+	if i := query.Get("i"); i != "" && (parsed.Kind == AppleURLKindAlbum || parsed.Kind == AppleURLKindPodcast) {
+		trackID, err := strconv.ParseInt(i, 10, 64)
+		if err != nil {
+			return AppleURL{}, fmt.Errorf("invalid Apple URL %q: track parameter i=%q is not numeric", rawURL, i)
+		}
+		parsed.ID = trackID
+		if parsed.Kind == AppleURLKindAlbum {
+			parsed.Entity = "song"
+		} else {
+			parsed.Entity = "podcastEpisode"
+		}
+	}
+
+	return parsed, nil
+}
+
+// BuildAppStoreURL returns the App Store URL for an app in the given storefront.
+// The slug is optional; Apple redirects URLs without one to the canonical page.
+func BuildAppStoreURL(country, slug string, trackID int64) string {
+	if slug == "" {
+		return fmt.Sprintf("https://apps.apple.com/%s/app/id%d", country, trackID)
+	}
+	return fmt.Sprintf("https://apps.apple.com/%s/app/%s/id%d", country, url.PathEscape(slug), trackID)
+}
@@ -0,0 +1,166 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+)
+
+func TestParseAppleURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected AppleURL
+	}{
+		{
+			url:      "https://apps.apple.com/us/app/pages/id361309726",
+			expected: AppleURL{Country: "us", Kind: "app", Slug: "pages", ID: 361309726, Media: "software", Entity: "software"},
+		},
+		{
+			url:      "https://apps.apple.com/gb/app/microsoft-word/id462054704?mt=8",
+			expected: AppleURL{Country: "gb", Kind: "app", Slug: "microsoft-word", ID: 462054704, Media: "software", Entity: "software"},
+		},
+		{
+			url:      "https://apps.apple.com/app/id361309726",
+			expected: AppleURL{Country: "jp", Kind: "app", ID: 361309726, Media: "software", Entity: "software"},
+		},
+		{
+			url:      "https://apps.apple.com/de/app/id999",
+			expected: AppleURL{Country: "de", Kind: "app", ID: 999, Media: "software", Entity: "software"},
+		},
+		{
+			url:      "https://itunes.apple.com/us/app/xcode/id497799835?mt=12&l=en",
+			expected: AppleURL{Country: "us", Kind: "app", Slug: "xcode", ID: 497799835, Media: "software", Entity: "macSoftware"},
+		},
+		{
+			url:      "http://itunes.apple.com/GB/app/pages/id361309726?ls=1",
+			expected: AppleURL{Country: "gb", Kind: "app", Slug: "pages", ID: 361309726, Media: "software", Entity: "software"},
+		},
+		{
+			url:      "https://apps.apple.com/us/developer/apple/id284417353",
+			expected: AppleURL{Country: "us", Kind: "developer", Slug: "apple", ID: 284417353, Media: "software", Entity: "softwareDeveloper"},
+		},
+		{
+			url:      "https://music.apple.com/us/album/in-between-dreams/1469577723",
+			expected: AppleURL{Country: "us", Kind: "album", Slug: "in-between-dreams", ID: 1469577723, Media: "music", Entity: "album"},
+		},
+		{
+			url:      "https://music.apple.com/gb/album/in-between-dreams/1469577723?i=1469577741",
+			expected: AppleURL{Country: "gb", Kind: "album", Slug: "in-between-dreams", ID: 1469577741, Media: "music", Entity: "song"},
+		},
+		{
+			url:      "https://geo.music.apple.com/us/artist/jack-johnson/909253",
+			expected: AppleURL{Country: "us", Kind: "artist", Slug: "jack-johnson", ID: 909253, Media: "music", Entity: "musicArtist"},
+		},
+		{
+			url:      "https://music.apple.com/us/music-video/upside-down/1445738051",
+			expected: AppleURL{Country: "us", Kind: "music-video", Slug: "upside-down", ID: 1445738051, Media: "musicVideo", Entity: "musicVideo"},
+		},
+		{
+			url:      "https://podcasts.apple.com/us/podcast/the-daily/id1200361736?i=1000600000000",
+			expected: AppleURL{Country: "us", Kind: "podcast", Slug: "the-daily", ID: 1000600000000, Media: "podcast", Entity: "podcastEpisode"},
+		},
+		{
+			url:      "https://books.apple.com/us/book/the-martian/id1435728082",
+			expected: AppleURL{Country: "us", Kind: "book", Slug: "the-martian", ID: 1435728082, Media: "ebook", Entity: "ebook"},
+		},
+		{
+			url:      "https://books.apple.com/gb/audiobook/the-martian/id1000000001",
+			expected: AppleURL{Country: "gb", Kind: "audiobook", Slug: "the-martian", ID: 1000000001, Media: "audiobook", Entity: "audiobook"},
+		},
+		{
+			url:      "https://books.apple.com/us/id1435728082",
+			expected: AppleURL{Country: "us", Kind: "book", ID: 1435728082, Media: "ebook", Entity: "ebook"},
+		},
+		{
+			url:      "https://books.apple.com/us/artist/andy-weir/id358708299",
+			expected: AppleURL{Country: "us", Kind: "artist", Slug: "andy-weir", ID: 358708299, Media: "ebook", Entity: "allArtist"},
+		},
+		{
+			url:      "https://podcasts.apple.com/us/id1200361736",
+			expected: AppleURL{Country: "us", Kind: "podcast", ID: 1200361736, Media: "podcast", Entity: "podcast"},
+		},
+		{
+			url:      "https://podcasts.apple.com/us/artist/the-new-york-times/id121664449",
+			expected: AppleURL{Country: "us", Kind: "artist", Slug: "the-new-york-times", ID: 121664449, Media: "podcast", Entity: "podcastAuthor"},
+		},
+		{
+			url:      "https://podcasts.apple.com/gb/episode/id1000600000000",
+			expected: AppleURL{Country: "gb", Kind: "episode", ID: 1000600000000, Media: "podcast", Entity: "podcastEpisode"},
+		},
+		{
+			url:      "https://itunes.apple.com/us/movie/the-martian/id1041176555",
+			expected: AppleURL{Country: "us", Kind: "movie", Slug: "the-martian", ID: 1041176555, Media: "movie", Entity: "movie"},
+		},
+		{
+			url:      "https://tv.apple.com/us/movie/id1041176555",
+			expected: AppleURL{Country: "us", Kind: "movie", ID: 1041176555, Media: "movie", Entity: "movie"},
+		},
+		{
+			url:      "https://itunes.apple.com/us/tv-season/the-office/id1000000002",
+			expected: AppleURL{Country: "us", Kind: "tv-season", Slug: "the-office", ID: 1000000002, Media: "tvShow", Entity: "tvSeason"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			parsed, err := ParseAppleURL(tt.url, "jp")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parsed != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestParseAppleURL_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"apps.apple.com/us/app/pages/id361309726",
+		"ftp://apps.apple.com/us/app/pages/id361309726",
+		"https://example.com/app/id123",
+		"https://apps.apple.com.example.com/us/app/pages/id361309726",
+		"https://apps.apple.com/us/app/pages",
+		"https://apps.apple.com/us/app/pages/idabc",
+		"https://apps.apple.com/us/story/id1234",
+		"https://apps.apple.com/us/app/a/b/id123",
+		"https://tv.apple.com/us/movie/the-martian/umc.cmc.3z9gw2ku4wqmj4lzsbatgaenq",
+		"https://music.apple.com/us/album/in-between-dreams/1469577723?i=abc",
+		"https://music.apple.com/us/1469577723",
+	}
+
+	for _, url := range tests {
+		t.Run(url, func(t *testing.T) {
+			if _, err := ParseAppleURL(url, DefaultCountry); err == nil {
+				t.Fatalf("expected error for %q", url)
+			}
+		})
+	}
+}
+
+func TestAppleURL_IsApp(t *testing.T) {
+	app, _ := ParseAppleURL("https://apps.apple.com/us/app/pages/id361309726", DefaultCountry)
+	album, _ := ParseAppleURL("https://music.apple.com/us/album/in-between-dreams/1469577723", DefaultCountry)
+	if !app.IsApp() || album.IsApp() {
+		t.Errorf("unexpected IsApp results: app=%v album=%v", app.IsApp(), album.IsApp())
+	}
+}
+
+func TestBuildAppStoreURL(t *testing.T) {
+	if got := BuildAppStoreURL("us", "pages", 361309726); got != "https://apps.apple.com/us/app/pages/id361309726" {
+		t.Errorf("unexpected URL %q", got)
+	}
+	if got := BuildAppStoreURL("gb", "", 361309726); got != "https://apps.apple.com/gb/app/id361309726" {
+		t.Errorf("unexpected URL without slug %q", got)
+	}
+
+	parsed, err := ParseAppleURL(BuildAppStoreURL("jp", "keynote", 361285480), DefaultCountry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed.Country != "jp" || parsed.Slug != "keynote" || parsed.ID != 361285480 {
+		t.Errorf("round trip mismatch: %+v", parsed)
+	}
+}
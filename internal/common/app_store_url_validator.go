@@ -0,0 +1,64 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure appleURLValidator satisfies the validator.String interface.
+var _ validator.String = appleURLValidator{}
+
+// appleURLValidator validates that a string attribute is an Apple media URL,
+// optionally restricted to App Store app links.
+type appleURLValidator struct {
+	appsOnly bool
+}
+
+// AppleURLValidator returns a validator that accepts any Apple media URL
+// understood by ParseAppleURL.
+func AppleURLValidator() validator.String {
+	return appleURLValidator{}
+}
+
+// AppStoreAppURLValidator returns a validator that accepts only App Store and
+// Mac App Store app links.
+func AppStoreAppURLValidator() validator.String {
+	return appleURLValidator{appsOnly: true}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v appleURLValidator) Description(ctx context.Context) string {
+	if v.appsOnly {
+		return "value must be an App Store app URL such as https://apps.apple.com/us/app/pages/id361309726"
+	}
+	return "value must be an Apple media URL such as https://apps.apple.com/us/app/pages/id361309726 or https://music.apple.com/us/album/name/1469577723"
+}
+
+// MarkdownDescription returns a markdown description of the validator's behavior.
+func (v appleURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as an Apple media URL.
+func (v appleURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	parsed, err := ParseAppleURL(req.ConfigValue.ValueString(), DefaultCountry)
+	if err == nil && v.appsOnly && !parsed.IsApp() {
+		err = fmt.Errorf("%q links to a %s, not an app", req.ConfigValue.ValueString(), parsed.Kind)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid App Store URL",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAppleURLValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		expectErr bool
+	}{
+		{"app URL", AppStoreAppURLValidator(), types.StringValue("https://itunes.apple.com/app/id361309726?mt=8"), false},
+		{"album URL for app", AppStoreAppURLValidator(), types.StringValue("https://music.apple.com/us/album/name/1469577723"), true},
+		{"album URL", AppleURLValidator(), types.StringValue("https://music.apple.com/us/album/name/1469577723"), false},
+		{"other host", AppleURLValidator(), types.StringValue("https://example.com/us/app/id1"), true},
+		{"null", AppleURLValidator(), types.StringNull(), false},
+		{"unknown", AppStoreAppURLValidator(), types.StringUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("url"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			tt.validator.ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("expected error %v, got %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultArtworkSize is the default edge length, in pixels, of downloaded artwork.
+const DefaultArtworkSize = 512
+
+// DefaultArtworkFormat is the default image format of downloaded artwork.
+const DefaultArtworkFormat = "png"
+
+// DefaultArtworkConcurrency is the default number of artwork images downloaded in parallel.
+const DefaultArtworkConcurrency = 8
+
+// ArtworkFormats lists the image formats Apple's artwork CDN can render.
+var ArtworkFormats = []string{"png", "jpg", "webp"}
+
+// artworkSizeRegex matches the trailing {width}x{height}{suffix}.{ext} segment of
+// an Apple artwork URL, e.g. 100x100bb.jpg.
+var artworkSizeRegex = regexp.MustCompile(`/(\d+)x(\d+)([a-z-]*)\.(jpg|jpeg|png|webp)$`)
+
+// ArtworkURL rewrites an Apple artwork URL (any of the artworkUrl* fields) to the
+// requested square size and image format. A size of zero keeps the original
+// dimensions. URLs that do not follow Apple's template only have their
+// extension replaced.
+func ArtworkURL(artworkURL string, size int64, format string) string {
+	if artworkURL == "" {
+		return ""
+	}
+	if format == "" {
+		format = DefaultArtworkFormat
+	}
+
+	if match := artworkSizeRegex.FindStringSubmatchIndex(artworkURL); match != nil {
+		width := artworkURL[match[2]:match[3]]
+		height := artworkURL[match[4]:match[5]]
+		suffix := artworkURL[match[6]:match[7]]
+		if size > 0 {
+			width = fmt.Sprintf("%d", size)
+			height = width
+		}
+		if suffix == "" {
+			suffix = "bb"
+		}
+		return fmt.Sprintf("%s/%sx%s%s.%s", artworkURL[:match[0]], width, height, suffix, format)
+	}
+
+	for _, ext := range []string{".jpg", ".jpeg", ".png", ".webp"} {
+		if strings.HasSuffix(artworkURL, ext) {
+			return strings.TrimSuffix(artworkURL, ext) + "." + format
+		}
+	}
+	return artworkURL
+}
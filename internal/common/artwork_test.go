@@ -0,0 +1,69 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+)
+
+func TestArtworkURL(t *testing.T) {
+	base := "https://is1-ssl.mzstatic.com/image/thumb/Purple/v4/ab/cd/source"
+	tests := []struct {
+		name     string
+		url      string
+		size     int64
+		format   string
+		expected string
+	}{
+		{
+			name:     "default rewrite to png",
+			url:      base + "/512x512bb.jpg",
+			size:     512,
+			format:   "png",
+			expected: base + "/512x512bb.png",
+		},
+		{
+			name:     "resize from 100",
+			url:      base + "/100x100bb.jpg",
+			size:     1024,
+			format:   "webp",
+			expected: base + "/1024x1024bb.webp",
+		},
+		{
+			name:     "keep size",
+			url:      base + "/60x60bb.jpg",
+			size:     0,
+			format:   "jpg",
+			expected: base + "/60x60bb.jpg",
+		},
+		{
+			name:     "empty format defaults to png",
+			url:      base + "/100x100bb.jpg",
+			size:     100,
+			expected: base + "/100x100bb.png",
+		},
+		{
+			name:     "non-template URL swaps extension",
+			url:      "https://example.com/artwork.jpg",
+			size:     512,
+			format:   "png",
+			expected: "https://example.com/artwork.png",
+		},
+		{
+			name:     "empty URL",
+			url:      "",
+			size:     512,
+			format:   "png",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArtworkURL(tt.url, tt.size, tt.format); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -7,23 +7,50 @@ package common
 
 import "time"
 
+// DefaultCountry is the storefront the iTunes Search API uses when no country is given.
+const DefaultCountry = "us"
+
 // MaxLookupBatchSize is the maximum number of items per iTunes lookup API request.
 const MaxLookupBatchSize = 200
 
-// RateLimitRequests is the maximum number of API requests allowed per rate limit window.
+// MaxMissingProbes is the maximum number of extra lookups made to identify
+// which UPCs, ISBNs, or AMG album or video IDs in a lookup returned no results.
+const MaxMissingProbes = 8
+
+// MaxSearchPageSize is the maximum number of results the iTunes search API returns per request.
+const MaxSearchPageSize = 200
+
+// RateLimitRequests is the default maximum number of API requests allowed per rate limit window.
 const RateLimitRequests = 20
 
-// RateLimitDuration is the time window for the rate limit request allowance.
+// RateLimitDuration is the default time window for the rate limit request allowance.
 const RateLimitDuration = 1 * time.Minute
 
-// MaxRetries is the maximum number of retry attempts for rate-limited (HTTP 429) responses.
+// MaxRetries is the default maximum number of attempts for rate-limited, server, and network errors.
 const MaxRetries = 5
 
-// MaxRetryWait is the maximum duration to wait between retries for rate-limited responses.
+// MaxRetryWait is the default maximum duration to wait between retries.
 const MaxRetryWait = 60 * time.Second
 
-// RetryBaseDelay is the initial backoff delay for retrying transient server errors (5xx).
+// RetryBaseDelay is the initial backoff ceiling for retrying rate-limited, server, and network errors.
 const RetryBaseDelay = 1 * time.Second
 
 // DefaultReadTimeout is the default timeout for data source read operations.
 const DefaultReadTimeout = 90 * time.Second
+
+// DefaultCacheTTL is the default lifetime of entries in the on-disk response cache.
+const DefaultCacheTTL = 1 * time.Hour
+
+// LookupConcurrency is the maximum number of lookup batches requested in parallel.
+const LookupConcurrency = 4
+
+// Values accepted by on_missing, controlling how lookups report selector values
+// that return no results.
+const (
+	OnMissingError  = "error"
+	OnMissingWarn   = "warn"
+	OnMissingIgnore = "ignore"
+)
+
+// OnMissingModes lists the supported on_missing values.
+var OnMissingModes = []string{OnMissingError, OnMissingWarn, OnMissingIgnore}
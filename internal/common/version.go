@@ -0,0 +1,123 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VersionRegex matches dotted version strings such as "14", "14.2.1", or "1.0b3".
+// Each component starts with a number and may carry an alphanumeric suffix.
+var VersionRegex = regexp.MustCompile(`^\d+[0-9A-Za-z-]*(\.\d+[0-9A-Za-z-]*)*$`)
+
+// versionComponentRegex splits a version component into its number and suffix.
+var versionComponentRegex = regexp.MustCompile(`^(\d+)(.*)$`)
+
+// versionSuffixRegex splits a suffix such as "b10" or "rc2" into runs of
+// letters and digits.
+var versionSuffixRegex = regexp.MustCompile(`\d+|\D+`)
+
+// CompareVersions compares two dotted version strings component by component,
+// numerically rather than lexically, so "10.0" is newer than "9.3". Missing
+// trailing components count as zero, so "17" equals "17.0". A component with a
+// suffix sorts before the same number without one, so "1.0b3" is older than
+// "1.0", and numbers within suffixes compare numerically, so "1.0b9" is older
+// than "1.0b10". It returns -1, 0, or 1 as a is older than, equal to, or newer than b.
+func CompareVersions(a, b string) (int, error) {
+	aParts, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bParts, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range max(len(aParts), len(bParts)) {
+		aPart, bPart := versionComponent{}, versionComponent{}
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if c := aPart.compare(bPart); c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// versionComponent is a single dot-separated part of a version string.
+type versionComponent struct {
+	number uint64
+	suffix string
+}
+
+// compare orders components by number, then places suffixed components before
+// unsuffixed ones and orders suffixes with compareSuffixes.
+func (v versionComponent) compare(other versionComponent) int {
+	if c := cmp.Compare(v.number, other.number); c != 0 {
+		return c
+	}
+	switch {
+	case v.suffix == other.suffix:
+		return 0
+	case v.suffix == "":
+		return 1
+	case other.suffix == "":
+		return -1
+	}
+	return compareSuffixes(v.suffix, other.suffix)
+}
+
+// compareSuffixes orders two suffixes run by run, comparing letter runs
+// lexically and digit runs numerically. A suffix that is a prefix of the other
+// sorts first, so "b" is older than "b1".
+func compareSuffixes(a, b string) int {
+	aRuns := versionSuffixRegex.FindAllString(a, -1)
+	bRuns := versionSuffixRegex.FindAllString(b, -1)
+	for i := range min(len(aRuns), len(bRuns)) {
+		aRun, bRun := aRuns[i], bRuns[i]
+		if isDigits(aRun) && isDigits(bRun) {
+			// Compare without parsing so long digit runs cannot overflow.
+			aRun, bRun = strings.TrimLeft(aRun, "0"), strings.TrimLeft(bRun, "0")
+			if c := cmp.Compare(len(aRun), len(bRun)); c != 0 {
+				return c
+			}
+		}
+		if c := cmp.Compare(aRun, bRun); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aRuns), len(bRuns))
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// parseVersion splits a version string into its components.
+func parseVersion(version string) ([]versionComponent, error) {
+	trimmed := strings.TrimSpace(version)
+	if !VersionRegex.MatchString(trimmed) {
+		return nil, fmt.Errorf("invalid version %q: expected dotted numeric components such as 14.2.1", version)
+	}
+
+	var parts []versionComponent
+	for component := range strings.SplitSeq(trimmed, ".") {
+		matches := versionComponentRegex.FindStringSubmatch(component)
+		number, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", version, err)
+		}
+		parts = append(parts, versionComponent{number: number, suffix: matches[2]})
+	}
+	return parts, nil
+}
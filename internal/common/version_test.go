@@ -0,0 +1,53 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "14.2", b: "14.2", expected: 0},
+		{a: "10.0", b: "9.3", expected: 1},
+		{a: "1.2.10", b: "1.2.9", expected: 1},
+		{a: "17", b: "17.0.0", expected: 0},
+		{a: "17.0.1", b: "17", expected: 1},
+		{a: "2024.10", b: "2025.1", expected: -1},
+		{a: "1.0b3", b: "1.0", expected: -1},
+		{a: "1.0b3", b: "1.0b10", expected: -1},
+		{a: "1.0b10", b: "1.0b9", expected: 1},
+		{a: "1.0b09", b: "1.0b9", expected: 0},
+		{a: "1.0a10", b: "1.0b2", expected: -1},
+		{a: "1.0b", b: "1.0b1", expected: -1},
+		{a: "1.0rc1", b: "1.0b10", expected: 1},
+		{a: "2.0-beta12", b: "2.0-beta2", expected: 1},
+		{a: "1.0b2a", b: "1.0b2", expected: 1},
+		{a: "1.0b99999999999999999999", b: "1.0b100000000000000000000", expected: -1},
+		{a: " 3.1 ", b: "3.1", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareVersions_Invalid(t *testing.T) {
+	for _, v := range []string{"", "v1.0", "1..2", "1.0.", "beta"} {
+		if _, err := CompareVersions(v, "1.0"); err == nil {
+			t.Errorf("expected error for version %q", v)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package diagnostics maps client errors to Terraform diagnostics.
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+)
+
+// APIError returns the diagnostic summary and detail for an error returned by
+// the client, so callers can write diags.AddError(diagnostics.APIError(err)).
+// Cancellations, exhausted rate-limit retries, server errors, and rejected
+// requests each get a distinct summary.
+func APIError(err error) (summary, detail string) {
+	if errors.Is(err, client.ErrCanceled) {
+		return "API Request Canceled", fmt.Sprintf(
+			"The request was canceled or timed out before the iTunes Search API responded. "+
+				"If this happens regularly, increase the read timeout in the data source's timeouts block.\n\n%s", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return "API Request Failed", err.Error()
+	}
+
+	switch {
+	case errors.Is(err, client.ErrRateLimited):
+		return "API Rate Limit Exceeded", fmt.Sprintf(
+			"The iTunes Search API kept responding with HTTP %d after %d attempts. "+
+				"Lower the provider's rate_limit_requests, raise max_retries, or retry later.\n\n%s", apiErr.StatusCode, apiErr.Attempts, err)
+	case apiErr.StatusCode >= http.StatusInternalServerError:
+		return "API Server Error", fmt.Sprintf(
+			"The iTunes Search API returned HTTP %d after %d attempts. This is usually temporary; retry later.\n\n%s", apiErr.StatusCode, apiErr.Attempts, err)
+	default:
+		return "API Request Rejected", fmt.Sprintf(
+			"The iTunes Search API rejected the request with HTTP %d. Check the data source arguments.\n\n%s", apiErr.StatusCode, err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "canceled",
+			err:      fmt.Errorf("lookup: %w", fmt.Errorf("%w: %w", client.ErrCanceled, context.DeadlineExceeded)),
+			expected: "API Request Canceled",
+		},
+		{
+			name:     "rate limited",
+			err:      &client.APIError{StatusCode: 429, Attempts: 5, Err: client.ErrRateLimited},
+			expected: "API Rate Limit Exceeded",
+		},
+		{
+			name:     "server error",
+			err:      fmt.Errorf("wrapped: %w", &client.APIError{StatusCode: 503, Attempts: 5}),
+			expected: "API Server Error",
+		},
+		{
+			name:     "rejected",
+			err:      &client.APIError{StatusCode: 400, Attempts: 1, Body: `{"errorMessage":"Invalid value(s) for key(s): [country]"}`},
+			expected: "API Request Rejected",
+		},
+		{
+			name:     "other",
+			err:      errors.New("error decoding API response"),
+			expected: "API Request Failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, detail := APIError(tt.err)
+			if summary != tt.expected {
+				t.Errorf("expected summary %q, got %q", tt.expected, summary)
+			}
+			if !strings.Contains(detail, tt.err.Error()) {
+				t.Errorf("expected detail to include the error, got %q", detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ function.Function = &ArtworkURLFunction{}
+
+// ArtworkURLFunction rewrites an Apple artwork URL to a given size and format.
+type ArtworkURLFunction struct{}
+
+// NewArtworkURLFunction returns a new instance of the artwork_url function.
+func NewArtworkURLFunction() function.Function {
+	return &ArtworkURLFunction{}
+}
+
+// Metadata sets the function name.
+func (f *ArtworkURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "artwork_url"
+}
+
+// Definition defines the function parameters and return type.
+func (f *ArtworkURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Resize an Apple artwork URL",
+		MarkdownDescription: "Rewrites an Apple artwork URL, such as any of the `artwork_url_*` result attributes, to the requested square size and image format. A size of 0 keeps the original dimensions.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "Artwork URL to rewrite.",
+			},
+			function.Int64Parameter{
+				Name:                "size",
+				MarkdownDescription: "Edge length in pixels of the square artwork, or 0 to keep the original dimensions.",
+			},
+			function.StringParameter{
+				Name:                "format",
+				MarkdownDescription: "Image format. Allowed values: png, jpg, webp.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the arguments and returns the rewritten URL.
+func (f *ArtworkURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var artworkURL, format string
+	var size int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &artworkURL, &size, &format))
+	if resp.Error != nil {
+		return
+	}
+
+	if size < 0 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("size must not be negative, got %d", size))
+		return
+	}
+	if !slices.Contains(common.ArtworkFormats, format) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("format must be one of %q, got %q", common.ArtworkFormats, format))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, common.ArtworkURL(artworkURL, size, format)))
+}
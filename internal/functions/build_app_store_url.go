@@ -0,0 +1,72 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ function.Function = &BuildAppStoreURLFunction{}
+
+// BuildAppStoreURLFunction builds an App Store URL from its components.
+type BuildAppStoreURLFunction struct{}
+
+// NewBuildAppStoreURLFunction returns a new instance of the build_app_store_url function.
+func NewBuildAppStoreURLFunction() function.Function {
+	return &BuildAppStoreURLFunction{}
+}
+
+// Metadata sets the function name.
+func (f *BuildAppStoreURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_app_store_url"
+}
+
+// Definition defines the function parameters and return type.
+func (f *BuildAppStoreURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an App Store URL",
+		MarkdownDescription: "Builds an App Store URL of the form `https://apps.apple.com/{country}/app/{slug}/id{id}`. Pass an empty `slug` to omit it; Apple redirects such URLs to the app's canonical page.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "country",
+				MarkdownDescription: "ISO 2-letter country code (lowercase) of the storefront.",
+			},
+			function.StringParameter{
+				Name:                "slug",
+				MarkdownDescription: "URL name of the app, such as `pages`. May be empty.",
+			},
+			function.Int64Parameter{
+				Name:                "id",
+				MarkdownDescription: "iTunes track ID of the app.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the components and returns the URL.
+func (f *BuildAppStoreURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var country, slug string
+	var id int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &country, &slug, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	if !common.CountryCodeRegex.MatchString(country) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("country must be a lowercase ISO 3166-1 alpha-2 country code, got %q", country))
+		return
+	}
+	if id < 1 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("id must be at least 1, got %d", id))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, common.BuildAppStoreURL(country, slug, id)))
+}
@@ -0,0 +1,72 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ function.Function = &CompareVersionsFunction{}
+
+// CompareVersionsFunction compares two dotted version strings.
+type CompareVersionsFunction struct{}
+
+// NewCompareVersionsFunction returns a new instance of the compare_versions function.
+func NewCompareVersionsFunction() function.Function {
+	return &CompareVersionsFunction{}
+}
+
+// Metadata sets the function name.
+func (f *CompareVersionsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "compare_versions"
+}
+
+// Definition defines the function parameters and return type.
+func (f *CompareVersionsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compare two version strings",
+		MarkdownDescription: "Compares two dotted version strings numerically, component by component, and returns -1, 0, or 1 as `a` is older than, equal to, or newer than `b`. Missing trailing components count as zero, so `17` equals `17.0`, and `10.0` is newer than `9.3`. Pre-release suffixes sort before the release and compare their numbers numerically, so `1.0b9` is older than `1.0b10`, which is older than `1.0`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "a",
+				MarkdownDescription: "First version.",
+			},
+			function.StringParameter{
+				Name:                "b",
+				MarkdownDescription: "Second version.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Run compares the versions.
+func (f *CompareVersionsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, v := range []string{a, b} {
+		if !common.VersionRegex.MatchString(strings.TrimSpace(v)) {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("invalid version %q: expected dotted numeric components such as 14.2.1", v))
+			return
+		}
+	}
+
+	c, err := common.CompareVersions(a, b)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, int64(c)))
+}
@@ -0,0 +1,62 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/provider"
+)
+
+// providerFactories returns a map of provider factories for acceptance tests.
+var providerFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"itunessearchapi": providerserver.NewProtocol6WithError(provider.New("test")()),
+}
+
+func TestAccFunctions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  parsed = provider::itunessearchapi::parse_app_store_url("https://apps.apple.com/gb/app/pages/id361309726")
+}
+
+output "id" {
+  value = local.parsed.id
+}
+
+output "url" {
+  value = provider::itunessearchapi::build_app_store_url("us", local.parsed.slug, local.parsed.id)
+}
+
+output "artwork" {
+  value = provider::itunessearchapi::artwork_url("https://example.com/image/100x100bb.jpg", 1024, "png")
+}
+
+output "newer" {
+  value = provider::itunessearchapi::compare_versions("10.0", "9.3")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("id", "361309726"),
+					resource.TestCheckOutput("url", "https://apps.apple.com/us/app/pages/id361309726"),
+					resource.TestCheckOutput("artwork", "https://example.com/image/1024x1024bb.png"),
+					resource.TestCheckOutput("newer", "1"),
+				),
+			},
+		},
+	})
+}
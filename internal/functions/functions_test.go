@@ -0,0 +1,132 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction runs f with the given arguments and returns the response.
+func runFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) *function.RunResponse {
+	t.Helper()
+
+	defResp := &function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, defResp)
+	if defResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected definition diagnostics: %v", defResp.Diagnostics)
+	}
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
+
+func TestFunctions_Metadata(t *testing.T) {
+	tests := map[string]function.Function{
+		"parse_app_store_url": NewParseAppStoreURLFunction(),
+		"build_app_store_url": NewBuildAppStoreURLFunction(),
+		"artwork_url":         NewArtworkURLFunction(),
+		"compare_versions":    NewCompareVersionsFunction(),
+	}
+
+	for expected, f := range tests {
+		resp := &function.MetadataResponse{}
+		f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+		if resp.Name != expected {
+			t.Errorf("expected function name %q, got %q", expected, resp.Name)
+		}
+	}
+}
+
+func TestParseAppStoreURLFunction(t *testing.T) {
+	resp := runFunction(t, NewParseAppStoreURLFunction(), types.ObjectUnknown(appStoreURLAttributeTypes),
+		types.StringValue("https://apps.apple.com/gb/app/pages/id361309726"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	expected := types.ObjectValueMust(appStoreURLAttributeTypes, map[string]attr.Value{
+		"country": types.StringValue("gb"),
+		"kind":    types.StringValue("app"),
+		"slug":    types.StringValue("pages"),
+		"id":      types.Int64Value(361309726),
+		"media":   types.StringValue("software"),
+		"entity":  types.StringValue("software"),
+	})
+	if !resp.Result.Value().Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, resp.Result.Value())
+	}
+}
+
+func TestParseAppStoreURLFunction_Invalid(t *testing.T) {
+	resp := runFunction(t, NewParseAppStoreURLFunction(), types.ObjectUnknown(appStoreURLAttributeTypes),
+		types.StringValue("https://example.com/app/id123"))
+	if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+		t.Fatalf("expected argument error for url, got %v", resp.Error)
+	}
+}
+
+func TestBuildAppStoreURLFunction(t *testing.T) {
+	resp := runFunction(t, NewBuildAppStoreURLFunction(), types.StringUnknown(),
+		types.StringValue("us"), types.StringValue("pages"), types.Int64Value(361309726))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.StringValue("https://apps.apple.com/us/app/pages/id361309726")) {
+		t.Errorf("unexpected URL %s", got)
+	}
+
+	resp = runFunction(t, NewBuildAppStoreURLFunction(), types.StringUnknown(),
+		types.StringValue("GB"), types.StringValue(""), types.Int64Value(1))
+	if resp.Error == nil || *resp.Error.FunctionArgument != 0 {
+		t.Fatalf("expected argument error for country, got %v", resp.Error)
+	}
+
+	resp = runFunction(t, NewBuildAppStoreURLFunction(), types.StringUnknown(),
+		types.StringValue("gb"), types.StringValue(""), types.Int64Value(0))
+	if resp.Error == nil || *resp.Error.FunctionArgument != 2 {
+		t.Fatalf("expected argument error for id, got %v", resp.Error)
+	}
+}
+
+func TestArtworkURLFunction(t *testing.T) {
+	resp := runFunction(t, NewArtworkURLFunction(), types.StringUnknown(),
+		types.StringValue("https://is1-ssl.mzstatic.com/image/thumb/Purple/v4/ab/cd/ef/AppIcon.png/100x100bb.jpg"),
+		types.Int64Value(1024), types.StringValue("webp"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	expected := types.StringValue("https://is1-ssl.mzstatic.com/image/thumb/Purple/v4/ab/cd/ef/AppIcon.png/1024x1024bb.webp")
+	if got := resp.Result.Value(); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	resp = runFunction(t, NewArtworkURLFunction(), types.StringUnknown(),
+		types.StringValue("https://example.com/100x100bb.jpg"), types.Int64Value(512), types.StringValue("gif"))
+	if resp.Error == nil || *resp.Error.FunctionArgument != 2 {
+		t.Fatalf("expected argument error for format, got %v", resp.Error)
+	}
+}
+
+func TestCompareVersionsFunction(t *testing.T) {
+	resp := runFunction(t, NewCompareVersionsFunction(), types.Int64Unknown(),
+		types.StringValue("10.0"), types.StringValue("9.3"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if got := resp.Result.Value(); !got.Equal(types.Int64Value(1)) {
+		t.Errorf("expected 1, got %s", got)
+	}
+
+	resp = runFunction(t, NewCompareVersionsFunction(), types.Int64Unknown(),
+		types.StringValue("1.0"), types.StringValue("latest"))
+	if resp.Error == nil || *resp.Error.FunctionArgument != 1 {
+		t.Fatalf("expected argument error for b, got %v", resp.Error)
+	}
+}
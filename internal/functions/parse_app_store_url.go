@@ -0,0 +1,87 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package functions implements the provider-defined functions.
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ function.Function = &ParseAppStoreURLFunction{}
+
+// appStoreURLAttributeTypes describes the object returned by parse_app_store_url.
+var appStoreURLAttributeTypes = map[string]attr.Type{
+	"country": types.StringType,
+	"kind":    types.StringType,
+	"slug":    types.StringType,
+	"id":      types.Int64Type,
+	"media":   types.StringType,
+	"entity":  types.StringType,
+}
+
+// ParseAppStoreURLFunction splits an App Store or other Apple media URL into its components.
+type ParseAppStoreURLFunction struct{}
+
+// NewParseAppStoreURLFunction returns a new instance of the parse_app_store_url function.
+func NewParseAppStoreURLFunction() function.Function {
+	return &ParseAppStoreURLFunction{}
+}
+
+// Metadata sets the function name.
+func (f *ParseAppStoreURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_app_store_url"
+}
+
+// Definition defines the function parameters and return type.
+func (f *ParseAppStoreURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse an App Store URL",
+		MarkdownDescription: "Splits an App Store URL such as `https://apps.apple.com/gb/app/pages/id361309726`, or any other Apple media URL on the itunes, music, podcasts, books, or tv.apple.com hosts, into an object with the storefront `country` (us when the URL omits it), the content `kind` from the path (such as `app` or `album`), the item name `slug` (empty when the URL omits it), the numeric iTunes `id`, and the iTunes Search API `media` and `entity` the link corresponds to.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "App Store or Apple media URL to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: appStoreURLAttributeTypes,
+		},
+	}
+}
+
+// Run parses the URL and returns its components.
+func (f *ParseAppStoreURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var urlStr string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &urlStr))
+	if resp.Error != nil {
+		return
+	}
+
+	parts, err := common.ParseAppleURL(urlStr, common.DefaultCountry)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(appStoreURLAttributeTypes, map[string]attr.Value{
+		"country": types.StringValue(parts.Country),
+		"kind":    types.StringValue(parts.Kind),
+		"slug":    types.StringValue(parts.Slug),
+		"id":      types.Int64Value(parts.ID),
+		"media":   types.StringValue(parts.Media),
+		"entity":  types.StringValue(parts.Entity),
+	})
+	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,290 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+// Environment variables that supply provider configuration when the
+// corresponding attribute is not set.
+const (
+	EnvBaseURL           = "ITUNES_BASE_URL"
+	EnvTimeout           = "ITUNES_TIMEOUT"
+	EnvMaxRetries        = "ITUNES_MAX_RETRIES"
+	EnvMaxRetryWait      = "ITUNES_MAX_RETRY_WAIT"
+	EnvRateLimitRequests = "ITUNES_RATE_LIMIT_REQUESTS"
+	EnvRateLimitDuration = "ITUNES_RATE_LIMIT_DURATION"
+	EnvRateLimitBackend  = "ITUNES_RATE_LIMIT_BACKEND"
+	EnvRateLimitFile     = "ITUNES_RATE_LIMIT_FILE"
+	EnvCacheDir          = "ITUNES_CACHE_DIR"
+	EnvCacheTTL          = "ITUNES_CACHE_TTL"
+	EnvCacheBypass       = "ITUNES_CACHE_BYPASS"
+	EnvFixtureMode       = "ITUNES_FIXTURE_MODE"
+	EnvFixtureDir        = "ITUNES_FIXTURE_DIR"
+	EnvDefaultCountry    = "ITUNES_DEFAULT_COUNTRY"
+	EnvLookupBatchWindow = "ITUNES_LOOKUP_BATCH_WINDOW"
+	EnvMetricsFile       = "ITUNES_METRICS_FILE"
+)
+
+// resolveClientConfig builds a client.Config from the provider configuration,
+// falling back to environment variables and then to the client defaults.
+func resolveClientConfig(data ITunesProviderModel) (client.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := client.DefaultConfig()
+
+	if v, ok := stringSetting(data.BaseURL, EnvBaseURL); ok {
+		if !isAbsoluteHTTPURL(v) {
+			diags.AddAttributeError(
+				path.Root("base_url"),
+				"Invalid Provider Configuration",
+				fmt.Sprintf("%q must be an absolute http or https URL (or set via %s), got %q.", "base_url", EnvBaseURL, v),
+			)
+		}
+		cfg.BaseURL = v
+	}
+
+	cfg.Timeout = durationSetting(&diags, "timeout", data.Timeout, EnvTimeout, cfg.Timeout)
+	cfg.MaxRetries = intSetting(&diags, "max_retries", data.MaxRetries, EnvMaxRetries, cfg.MaxRetries)
+	cfg.MaxRetryWait = durationSetting(&diags, "max_retry_wait", data.MaxRetryWait, EnvMaxRetryWait, cfg.MaxRetryWait)
+	cfg.RateLimitRequests = intSetting(&diags, "rate_limit_requests", data.RateLimitRequests, EnvRateLimitRequests, cfg.RateLimitRequests)
+	cfg.RateLimitDuration = durationSetting(&diags, "rate_limit_duration", data.RateLimitDuration, EnvRateLimitDuration, cfg.RateLimitDuration)
+
+	if v, ok := stringSetting(data.RateLimitBackend, EnvRateLimitBackend); ok {
+		if !slices.Contains(client.RateLimitBackends, v) {
+			diags.AddAttributeError(
+				path.Root("rate_limit_backend"),
+				"Invalid Provider Configuration",
+				fmt.Sprintf("%q must be one of %q (or set via %s), got %q.", "rate_limit_backend", client.RateLimitBackends, EnvRateLimitBackend, v),
+			)
+		}
+		cfg.RateLimitBackend = v
+	}
+	if v, ok := stringSetting(data.RateLimitFile, EnvRateLimitFile); ok {
+		cfg.RateLimitFile = v
+	}
+	if cfg.RateLimitBackend == client.RateLimitBackendFile && cfg.RateLimitFile == "" {
+		diags.AddAttributeError(
+			path.Root("rate_limit_file"),
+			"Invalid Provider Configuration",
+			fmt.Sprintf("%q (or %s) must be set when rate_limit_backend is %q.", "rate_limit_file", EnvRateLimitFile, cfg.RateLimitBackend),
+		)
+	}
+
+	if v, ok := stringSetting(data.CacheDir, EnvCacheDir); ok {
+		cfg.CacheDir = v
+	}
+	cfg.CacheTTL = durationSetting(&diags, "cache_ttl", data.CacheTTL, EnvCacheTTL, cfg.CacheTTL)
+	cfg.CacheBypass = boolSetting(&diags, "cache_bypass", data.CacheBypass, EnvCacheBypass, cfg.CacheBypass)
+
+	if v, ok := stringSetting(data.FixtureMode, EnvFixtureMode); ok {
+		if !slices.Contains(client.FixtureModes, v) {
+			diags.AddAttributeError(
+				path.Root("fixture_mode"),
+				"Invalid Provider Configuration",
+				fmt.Sprintf("%q must be one of %q (or set via %s), got %q.", "fixture_mode", client.FixtureModes, EnvFixtureMode, v),
+			)
+		}
+		cfg.FixtureMode = v
+	}
+	if v, ok := stringSetting(data.FixtureDir, EnvFixtureDir); ok {
+		cfg.FixtureDir = v
+	}
+	if cfg.FixtureMode != "" && cfg.FixtureDir == "" {
+		diags.AddAttributeError(
+			path.Root("fixture_dir"),
+			"Invalid Provider Configuration",
+			fmt.Sprintf("%q (or %s) must be set when fixture_mode is %q.", "fixture_dir", EnvFixtureDir, cfg.FixtureMode),
+		)
+	}
+
+	if v, ok := stringSetting(data.DefaultCountry, EnvDefaultCountry); ok {
+		if !common.CountryCodeRegex.MatchString(v) {
+			diags.AddAttributeError(
+				path.Root("default_country"),
+				"Invalid Provider Configuration",
+				fmt.Sprintf("%q must be a lowercase ISO 3166-1 alpha-2 country code such as \"gb\" (or set via %s), got %q.", "default_country", EnvDefaultCountry, v),
+			)
+		}
+		cfg.DefaultCountry = v
+	}
+
+	cfg.LookupBatchWindow = durationSetting(&diags, "lookup_batch_window", data.LookupBatchWindow, EnvLookupBatchWindow, cfg.LookupBatchWindow)
+
+	return cfg, diags
+}
+
+// stringSetting returns the configured value, or the environment variable when
+// the attribute is null or unknown. The boolean reports whether either was set.
+func stringSetting(v types.String, envVar string) (string, bool) {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueString(), true
+	}
+	if env, ok := os.LookupEnv(envVar); ok && env != "" {
+		return env, true
+	}
+	return "", false
+}
+
+// durationSetting resolves a duration attribute, recording a diagnostic when the
+// value cannot be parsed or is not positive.
+func durationSetting(diags *diag.Diagnostics, attr string, v types.String, envVar string, fallback time.Duration) time.Duration {
+	raw, ok := stringSetting(v, envVar)
+	if !ok {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid Provider Configuration",
+			fmt.Sprintf("%q must be a positive duration such as \"30s\" (or set via %s), got %q.", attr, envVar, raw),
+		)
+		return fallback
+	}
+	return d
+}
+
+// intSetting resolves an integer attribute, recording a diagnostic when the
+// environment variable cannot be parsed or the value is not positive.
+func intSetting(diags *diag.Diagnostics, attr string, v types.Int64, envVar string, fallback int) int {
+	if !v.IsNull() && !v.IsUnknown() {
+		if v.ValueInt64() < 1 {
+			diags.AddAttributeError(path.Root(attr), "Invalid Provider Configuration",
+				fmt.Sprintf("%q must be at least 1, got %d.", attr, v.ValueInt64()))
+			return fallback
+		}
+		return int(v.ValueInt64())
+	}
+
+	env, ok := os.LookupEnv(envVar)
+	if !ok || env == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(env)
+	if err != nil || n < 1 {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid Provider Configuration",
+			fmt.Sprintf("%s must be a positive integer, got %q.", envVar, env),
+		)
+		return fallback
+	}
+	return n
+}
+
+// boolSetting resolves a boolean attribute, recording a diagnostic when the
+// environment variable cannot be parsed.
+func boolSetting(diags *diag.Diagnostics, attr string, v types.Bool, envVar string, fallback bool) bool {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueBool()
+	}
+
+	env, ok := os.LookupEnv(envVar)
+	if !ok || env == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid Provider Configuration",
+			fmt.Sprintf("%s must be a boolean such as \"true\" or \"false\", got %q.", envVar, env),
+		)
+		return fallback
+	}
+	return b
+}
+
+// Ensure durationValidator satisfies the validator.String interface.
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string attribute is a positive Go duration.
+type durationValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration string such as \"30s\" or \"1m\""
+}
+
+// MarkdownDescription returns a markdown description of the validator's behavior.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value parses as a positive duration.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// isAbsoluteHTTPURL reports whether raw parses as an http or https URL with a
+// host.
+func isAbsoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Ensure baseURLValidator satisfies the validator.String interface.
+var _ validator.String = baseURLValidator{}
+
+// baseURLValidator validates that a string attribute is an absolute http or
+// https URL.
+type baseURLValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v baseURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL such as \"https://itunes.apple.com\""
+}
+
+// MarkdownDescription returns a markdown description of the validator's behavior.
+func (v baseURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString checks that the configured value has an http or https scheme
+// and a host.
+func (v baseURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isAbsoluteHTTPURL(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+)
+
+func nullProviderModel() ITunesProviderModel {
+	return ITunesProviderModel{
+		BaseURL:           types.StringNull(),
+		Timeout:           types.StringNull(),
+		MaxRetries:        types.Int64Null(),
+		MaxRetryWait:      types.StringNull(),
+		RateLimitRequests: types.Int64Null(),
+		RateLimitDuration: types.StringNull(),
+		RateLimitBackend:  types.StringNull(),
+		RateLimitFile:     types.StringNull(),
+		CacheDir:          types.StringNull(),
+		CacheTTL:          types.StringNull(),
+		CacheBypass:       types.BoolNull(),
+		FixtureMode:       types.StringNull(),
+		FixtureDir:        types.StringNull(),
+		DefaultCountry:    types.StringNull(),
+		LookupBatchWindow: types.StringNull(),
+		MetricsFile:       types.StringNull(),
+	}
+}
+
+func TestResolveClientConfig_Defaults(t *testing.T) {
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg != client.DefaultConfig() {
+		t.Errorf("expected default config, got %+v", cfg)
+	}
+}
+
+func TestResolveClientConfig_Attributes(t *testing.T) {
+	data := nullProviderModel()
+	data.BaseURL = types.StringValue("https://mirror.example.com")
+	data.Timeout = types.StringValue("10s")
+	data.MaxRetries = types.Int64Value(2)
+	data.MaxRetryWait = types.StringValue("5s")
+	data.RateLimitRequests = types.Int64Value(5)
+	data.RateLimitDuration = types.StringValue("2m")
+	data.CacheDir = types.StringValue("/tmp/itunes-cache")
+	data.CacheTTL = types.StringValue("6h")
+	data.CacheBypass = types.BoolValue(true)
+
+	cfg, diags := resolveClientConfig(data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := client.Config{
+		BaseURL:           "https://mirror.example.com",
+		Timeout:           10 * time.Second,
+		MaxRetries:        2,
+		MaxRetryWait:      5 * time.Second,
+		RateLimitRequests: 5,
+		RateLimitDuration: 2 * time.Minute,
+		CacheDir:          "/tmp/itunes-cache",
+		CacheTTL:          6 * time.Hour,
+		CacheBypass:       true,
+	}
+	if cfg != expected {
+		t.Errorf("expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestResolveClientConfig_EnvironmentFallback(t *testing.T) {
+	t.Setenv(EnvBaseURL, "https://env.example.com")
+	t.Setenv(EnvRateLimitRequests, "7")
+	t.Setenv(EnvRateLimitDuration, "30s")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.BaseURL != "https://env.example.com" {
+		t.Errorf("expected base URL from environment, got %q", cfg.BaseURL)
+	}
+	if cfg.RateLimitRequests != 7 {
+		t.Errorf("expected 7 rate limit requests, got %d", cfg.RateLimitRequests)
+	}
+	if cfg.RateLimitDuration != 30*time.Second {
+		t.Errorf("expected 30s rate limit duration, got %s", cfg.RateLimitDuration)
+	}
+}
+
+func TestResolveClientConfig_AttributeOverridesEnvironment(t *testing.T) {
+	t.Setenv(EnvMaxRetries, "9")
+
+	data := nullProviderModel()
+	data.MaxRetries = types.Int64Value(3)
+
+	cfg, diags := resolveClientConfig(data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.MaxRetries != 3 {
+		t.Errorf("expected attribute value 3 to win, got %d", cfg.MaxRetries)
+	}
+}
+
+func TestResolveClientConfig_InvalidEnvironment(t *testing.T) {
+	t.Setenv(EnvTimeout, "soon")
+	t.Setenv(EnvMaxRetries, "many")
+	t.Setenv(EnvCacheBypass, "sometimes")
+
+	_, diags := resolveClientConfig(nullProviderModel())
+	if diags.ErrorsCount() != 3 {
+		t.Errorf("expected 3 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_CacheEnvironment(t *testing.T) {
+	t.Setenv(EnvCacheDir, "/var/cache/itunes")
+	t.Setenv(EnvCacheTTL, "30m")
+	t.Setenv(EnvCacheBypass, "true")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.CacheDir != "/var/cache/itunes" {
+		t.Errorf("expected cache dir from environment, got %q", cfg.CacheDir)
+	}
+	if cfg.CacheTTL != 30*time.Minute {
+		t.Errorf("expected 30m cache TTL, got %s", cfg.CacheTTL)
+	}
+	if !cfg.CacheBypass {
+		t.Error("expected cache bypass from environment")
+	}
+}
+
+func TestResolveClientConfig_FixtureEnvironment(t *testing.T) {
+	t.Setenv(EnvFixtureMode, "replay")
+	t.Setenv(EnvFixtureDir, "/srv/fixtures")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.FixtureMode != client.FixtureModeReplay {
+		t.Errorf("expected replay fixture mode, got %q", cfg.FixtureMode)
+	}
+	if cfg.FixtureDir != "/srv/fixtures" {
+		t.Errorf("expected fixture dir from environment, got %q", cfg.FixtureDir)
+	}
+}
+
+func TestResolveClientConfig_FixtureModeRequiresDir(t *testing.T) {
+	data := nullProviderModel()
+	data.FixtureMode = types.StringValue("record")
+
+	_, diags := resolveClientConfig(data)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_InvalidFixtureModeEnvironment(t *testing.T) {
+	t.Setenv(EnvFixtureMode, "playback")
+	t.Setenv(EnvFixtureDir, "/srv/fixtures")
+
+	_, diags := resolveClientConfig(nullProviderModel())
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_RateLimitBackend(t *testing.T) {
+	t.Setenv(EnvRateLimitBackend, "file")
+	t.Setenv(EnvRateLimitFile, "/var/run/itunes/rate-limit.json")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.RateLimitBackend != client.RateLimitBackendFile {
+		t.Errorf("expected file backend, got %q", cfg.RateLimitBackend)
+	}
+	if cfg.RateLimitFile != "/var/run/itunes/rate-limit.json" {
+		t.Errorf("expected rate limit file from environment, got %q", cfg.RateLimitFile)
+	}
+
+	data := nullProviderModel()
+	data.RateLimitBackend = types.StringValue("memory")
+	cfg, diags = resolveClientConfig(data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.RateLimitBackend != client.RateLimitBackendMemory {
+		t.Errorf("expected attribute to override environment, got %q", cfg.RateLimitBackend)
+	}
+}
+
+func TestResolveClientConfig_FileRateLimitBackendRequiresFile(t *testing.T) {
+	data := nullProviderModel()
+	data.RateLimitBackend = types.StringValue("file")
+
+	_, diags := resolveClientConfig(data)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_InvalidRateLimitBackendEnvironment(t *testing.T) {
+	t.Setenv(EnvRateLimitBackend, "redis")
+
+	_, diags := resolveClientConfig(nullProviderModel())
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_DefaultCountry(t *testing.T) {
+	t.Setenv(EnvDefaultCountry, "gb")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.DefaultCountry != "gb" {
+		t.Errorf("expected default country from environment, got %q", cfg.DefaultCountry)
+	}
+
+	data := nullProviderModel()
+	data.DefaultCountry = types.StringValue("de")
+	cfg, diags = resolveClientConfig(data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.DefaultCountry != "de" {
+		t.Errorf("expected attribute to override environment, got %q", cfg.DefaultCountry)
+	}
+}
+
+func TestResolveClientConfig_InvalidDefaultCountryEnvironment(t *testing.T) {
+	t.Setenv(EnvDefaultCountry, "GBR")
+
+	_, diags := resolveClientConfig(nullProviderModel())
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestResolveClientConfig_InvalidBaseURLEnvironment(t *testing.T) {
+	for _, v := range []string{"itunes.example.com", "https://", "http://?x", "https:// ", "ftp://itunes.example.com"} {
+		t.Run(v, func(t *testing.T) {
+			t.Setenv(EnvBaseURL, v)
+
+			_, diags := resolveClientConfig(nullProviderModel())
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+			}
+			if got := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root("base_url")) {
+				t.Errorf("expected error on base_url, got %s", got)
+			}
+		})
+	}
+}
+
+func TestBaseURLValidator(t *testing.T) {
+	tests := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{value: types.StringValue("https://itunes.apple.com"), wantErr: false},
+		{value: types.StringValue("http://localhost:8080/mirror"), wantErr: false},
+		{value: types.StringNull(), wantErr: false},
+		{value: types.StringValue("https://"), wantErr: true},
+		{value: types.StringValue("http://?x"), wantErr: true},
+		{value: types.StringValue("https:// "), wantErr: true},
+		{value: types.StringValue("itunes.apple.com"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("base_url"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			baseURLValidator{}.ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestResolveClientConfig_LookupBatchWindow(t *testing.T) {
+	t.Setenv(EnvLookupBatchWindow, "50ms")
+
+	cfg, diags := resolveClientConfig(nullProviderModel())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if cfg.LookupBatchWindow != 50*time.Millisecond {
+		t.Errorf("expected batch window from environment, got %s", cfg.LookupBatchWindow)
+	}
+
+	t.Setenv(EnvLookupBatchWindow, "soon")
+	_, diags = resolveClientConfig(nullProviderModel())
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
@@ -6,17 +6,52 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/functions"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/resources/app"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/resources/appavailability"
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/resources/content"
 )
 
-// Ensure ITunesProvider satisfies the provider.Provider interface.
-var _ provider.Provider = &ITunesProvider{}
+// Ensure ITunesProvider satisfies the provider.Provider and
+// provider.ProviderWithFunctions interfaces.
+var (
+	_ provider.Provider              = &ITunesProvider{}
+	_ provider.ProviderWithFunctions = &ITunesProvider{}
+)
+
+// ITunesProviderModel describes the provider-level configuration.
+type ITunesProviderModel struct {
+	BaseURL           types.String `tfsdk:"base_url"`
+	Timeout           types.String `tfsdk:"timeout"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	MaxRetryWait      types.String `tfsdk:"max_retry_wait"`
+	RateLimitRequests types.Int64  `tfsdk:"rate_limit_requests"`
+	RateLimitDuration types.String `tfsdk:"rate_limit_duration"`
+	RateLimitBackend  types.String `tfsdk:"rate_limit_backend"`
+	RateLimitFile     types.String `tfsdk:"rate_limit_file"`
+	CacheDir          types.String `tfsdk:"cache_dir"`
+	CacheTTL          types.String `tfsdk:"cache_ttl"`
+	CacheBypass       types.Bool   `tfsdk:"cache_bypass"`
+	FixtureMode       types.String `tfsdk:"fixture_mode"`
+	FixtureDir        types.String `tfsdk:"fixture_dir"`
+	DefaultCountry    types.String `tfsdk:"default_country"`
+	LookupBatchWindow types.String `tfsdk:"lookup_batch_window"`
+	MetricsFile       types.String `tfsdk:"metrics_file"`
+}
 
 // ITunesProvider defines the provider implementation.
 type ITunesProvider struct {
@@ -34,14 +69,172 @@ func (p *ITunesProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 func (p *ITunesProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Interact with the iTunes Search API: https://performance-partners.apple.com/search-api",
-		Attributes:  map[string]schema.Attribute{},
+		Attributes: map[string]schema.Attribute{
+			"base_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Base URL of the iTunes Search API, for example an internal mirror. Defaults to `https://itunes.apple.com`. May also be set with the `ITUNES_BASE_URL` environment variable.",
+				Validators: []validator.String{
+					baseURLValidator{},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Timeout for each HTTP request, as a Go duration string (e.g. `30s`). Defaults to `30s`. May also be set with the `ITUNES_TIMEOUT` environment variable.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of attempts for rate-limited (HTTP 429) and server error (HTTP 5xx) responses and transient network errors. Retries honour `Retry-After`, given in seconds or as an HTTP date, and otherwise back off exponentially with random jitter. Defaults to `5`. May also be set with the `ITUNES_MAX_RETRIES` environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retry_wait": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum time to wait between retries, as a Go duration string (e.g. `60s`). Defaults to `60s`. May also be set with the `ITUNES_MAX_RETRY_WAIT` environment variable.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"rate_limit_requests": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of requests allowed per `rate_limit_duration`. The limiter pauses every request whenever the API responds with HTTP 429 or sends `Retry-After` with a server error, and halves its rate once per burst of such responses, then gradually returns to this rate as requests succeed. Retries count against the limit like any other request. Rate changes are shown in debug logs. Defaults to `20`. May also be set with the `ITUNES_RATE_LIMIT_REQUESTS` environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rate_limit_duration": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time window for `rate_limit_requests`, as a Go duration string (e.g. `1m`). Defaults to `1m`. May also be set with the `ITUNES_RATE_LIMIT_DURATION` environment variable.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"rate_limit_backend": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Where the rate limiter keeps its budget. `memory` limits each provider process on its own; `file` shares one budget, kept in `rate_limit_file`, between every provider process on the host, such as parallel Terraform runs on a CI server. Processes sharing a file should use the same `rate_limit_requests` and `rate_limit_duration`. Defaults to `memory`. May also be set with the `ITUNES_RATE_LIMIT_BACKEND` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.RateLimitBackends...),
+				},
+			},
+			"rate_limit_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the file holding the shared rate limit budget. Required when `rate_limit_backend` is `file`. May also be set with the `ITUNES_RATE_LIMIT_FILE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"cache_dir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Directory for the on-disk response cache. When set, search and lookup responses are cached and shared between Terraform runs and parallel processes. Caching is disabled by default. May also be set with the `ITUNES_CACHE_DIR` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"cache_ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long cached responses remain fresh, as a Go duration string (e.g. `1h`). Expired entries are deleted when read or when the provider next starts. Defaults to `1h`. May also be set with the `ITUNES_CACHE_TTL` environment variable.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"cache_bypass": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, cached responses are ignored and every request goes to the API. Fresh responses are still written to `cache_dir`, refreshing the cache for later runs. Defaults to `false`. May also be set with the `ITUNES_CACHE_BYPASS` environment variable.",
+			},
+			"fixture_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Record or replay HTTP fixtures in `fixture_dir`. `record` performs real requests and saves the final successful or 404 response to every search, lookup, and artwork request; `replay` serves saved responses without any network access and fails requests that were not recorded. May also be set with the `ITUNES_FIXTURE_MODE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.FixtureModes...),
+				},
+			},
+			"fixture_dir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Directory holding recorded HTTP fixtures. Required when `fixture_mode` is set. May also be set with the `ITUNES_FIXTURE_DIR` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"default_country": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ISO 2-letter country code (lowercase) of the storefront queried when a data source or App Store URL does not name one. Defaults to the API default, `us`. May also be set with the `ITUNES_DEFAULT_COUNTRY` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(common.CountryCodeRegex, "must be a valid ISO 3166-1 alpha-2 country code"),
+				},
+			},
+			"lookup_batch_window": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Enables lookup batching, as a Go duration string (e.g. `50ms`). Lookups by iTunes ID, bundle ID, or AMG artist ID that arrive within this window, from any data source, are merged into one request per selector, media, entity, and country, and their results split back to each data source. Lookups that set a `sort` order, or a `limit` that could cut results, are sent on their own. Batching is disabled by default. May also be set with the `ITUNES_LOOKUP_BATCH_WINDOW` environment variable.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"metrics_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "File to append request metrics to, one JSON object per line, such as to find out where a slow plan spends its time. Each search, lookup, and artwork request records its latency, attempts and retries, rate limiter wait, status code, cache hit, and bytes downloaded. Several provider processes may share the file. The same metrics are always written to the debug log. May also be set with the `ITUNES_METRICS_FILE` environment variable.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
 	}
 }
 
 // Configure initializes the API client and makes it available to data sources.
 func (p *ITunesProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	clientObj := client.NewClient()
-	clientObj.SetLogger(NewTerraformLogger())
+	var data ITunesProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg, diags := resolveClientConfig(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metricsFile, hasMetricsFile := stringSetting(data.MetricsFile, EnvMetricsFile)
+
+	tflog.Debug(ctx, "Configuring iTunes Search API client", map[string]any{
+		"base_url":            cfg.BaseURL,
+		"timeout":             cfg.Timeout.String(),
+		"max_retries":         cfg.MaxRetries,
+		"max_retry_wait":      cfg.MaxRetryWait.String(),
+		"rate_limit_requests": cfg.RateLimitRequests,
+		"rate_limit_duration": cfg.RateLimitDuration.String(),
+		"rate_limit_backend":  cfg.RateLimitBackend,
+		"rate_limit_file":     cfg.RateLimitFile,
+		"cache_dir":           cfg.CacheDir,
+		"cache_ttl":           cfg.CacheTTL.String(),
+		"cache_bypass":        cfg.CacheBypass,
+		"fixture_mode":        cfg.FixtureMode,
+		"fixture_dir":         cfg.FixtureDir,
+		"default_country":     cfg.DefaultCountry,
+		"lookup_batch_window": cfg.LookupBatchWindow.String(),
+		"metrics_file":        metricsFile,
+	})
+
+	logger := NewTerraformLogger()
+	clientObj := client.NewClientWithConfig(cfg)
+	clientObj.SetLogger(logger)
+	clientObj.SetHooks(logger)
+
+	if hasMetricsFile {
+		fileHooks, err := client.NewFileHooks(metricsFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metrics_file"),
+				"Unable to Open Metrics File",
+				err.Error(),
+			)
+			return
+		}
+		clientObj.SetHooks(client.MultiHooks(logger, fileHooks))
+	}
 
 	p.client = clientObj
 	resp.DataSourceData = clientObj
@@ -57,6 +250,18 @@ func (p *ITunesProvider) Resources(ctx context.Context) []func() resource.Resour
 func (p *ITunesProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		content.NewContentDataSource,
+		app.NewAppDataSource,
+		appavailability.NewAppAvailabilityDataSource,
+	}
+}
+
+// Functions returns the provider's functions.
+func (p *ITunesProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewParseAppStoreURLFunction,
+		functions.NewBuildAppStoreURLFunction,
+		functions.NewArtworkURLFunction,
+		functions.NewCompareVersionsFunction,
 	}
 }
 
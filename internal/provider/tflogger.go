@@ -12,8 +12,11 @@ import (
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
 )
 
-// Ensure TerraformLogger implements client.Logger interface
-var _ client.Logger = (*TerraformLogger)(nil)
+// Ensure TerraformLogger implements the client.Logger and client.Hooks interfaces
+var (
+	_ client.Logger = (*TerraformLogger)(nil)
+	_ client.Hooks  = (*TerraformLogger)(nil)
+)
 
 // TerraformLogger implements the client.Logger interface using tflog
 type TerraformLogger struct{}
@@ -83,7 +86,28 @@ func (l *TerraformLogger) LogResponse(ctx context.Context, statusCode int, heade
 	tflog.Debug(ctx, "HTTP Response", fields)
 }
 
-// LogAuth logs authentication-related events using tflog at DEBUG level
-func (l *TerraformLogger) LogAuth(ctx context.Context, message string, fields map[string]any) {
+// LogEvent logs client events such as retries, cache hits, and rate limit changes using tflog at DEBUG level
+func (l *TerraformLogger) LogEvent(ctx context.Context, message string, fields map[string]any) {
 	tflog.Debug(ctx, message, fields)
 }
+
+// RequestCompleted logs the metrics of a completed request using tflog at DEBUG level
+func (l *TerraformLogger) RequestCompleted(ctx context.Context, m client.RequestMetrics) {
+	fields := map[string]any{
+		"operation":       m.Operation,
+		"url":             m.URL,
+		"status_code":     m.StatusCode,
+		"attempts":        m.Attempts,
+		"retries":         m.Retries(),
+		"latency":         m.Latency.String(),
+		"rate_limit_wait": m.RateLimitWait.String(),
+		"cache_hit":       m.CacheHit,
+		"shared":          m.Shared,
+		"bytes":           m.Bytes,
+	}
+	if m.Err != nil {
+		fields["error"] = m.Err.Error()
+	}
+
+	tflog.Debug(ctx, "HTTP Request Metrics", fields)
+}
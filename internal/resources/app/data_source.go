@@ -0,0 +1,307 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ datasource.DataSource = &AppDataSource{}
+
+// AppDataSource defines the data source implementation.
+type AppDataSource struct {
+	client *client.Client
+}
+
+// NewAppDataSource returns a new instance of the app data source.
+func NewAppDataSource() datasource.DataSource {
+	return &AppDataSource{}
+}
+
+// Metadata sets the data source type name.
+func (d *AppDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+// Schema defines the data source schema.
+func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a single App Store app by bundle ID, track ID, or App Store URL.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx),
+			"bundle_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Bundle ID of the app. Exactly one of `bundle_id`, `track_id`, or `app_store_url` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("track_id"),
+						path.MatchRoot("app_store_url"),
+					),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"track_id": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "iTunes track ID of the app. Exactly one of `bundle_id`, `track_id`, or `app_store_url` must be set.",
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(
+						path.MatchRoot("bundle_id"),
+						path.MatchRoot("app_store_url"),
+					),
+					int64validator.AtLeast(1),
+				},
+			},
+			"app_store_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "App Store or Mac App Store URL of the app, on apps.apple.com or itunes.apple.com. The storefront country is taken from the URL, or the provider's `default_country` when the URL has none. `?mt=12` marks a Mac App Store app and is looked up with the `macSoftware` entity; other query strings are ignored. Exactly one of `bundle_id`, `track_id`, or `app_store_url` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("bundle_id"),
+						path.MatchRoot("track_id"),
+					),
+					common.AppStoreAppURLValidator(),
+				},
+			},
+			"country": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ISO 2-letter country code (lowercase) of the storefront to query. Defaults to the country in `app_store_url`, or the provider's `default_country`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("app_store_url"),
+					),
+					stringvalidator.RegexMatches(common.CountryCodeRegex, "must be a valid ISO 3166-1 alpha-2 country code"),
+				},
+			},
+			"minimum_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Lowest acceptable app version. The read fails when the storefront's `version` is older. Versions are compared numerically component by component, so 10.0 is newer than 9.3.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("exact_version"),
+					),
+					stringvalidator.RegexMatches(common.VersionRegex, "must be a dotted version such as 14.2.1"),
+				},
+			},
+			"exact_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Required app version. The read fails unless the storefront's `version` is equal to it, ignoring trailing zero components (so 17 matches 17.0).",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("minimum_version"),
+					),
+					stringvalidator.RegexMatches(common.VersionRegex, "must be a dotted version such as 14.2.1"),
+				},
+			},
+			"supported_os_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "OS version the app must support, such as the oldest OS version in your fleet. The read fails when the app's `minimum_os_version` is newer.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(common.VersionRegex, "must be a dotted version such as 17.4"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the app.",
+				Computed:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of content (software or mac-software).",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Current version.",
+				Computed:            true,
+			},
+			"minimum_os_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum OS version required.",
+				Computed:            true,
+			},
+			"file_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "File size in bytes.",
+				Computed:            true,
+			},
+			"seller_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the seller.",
+				Computed:            true,
+			},
+			"seller_url": schema.StringAttribute{
+				MarkdownDescription: "URL to the seller's website.",
+				Computed:            true,
+			},
+			"artist_id": schema.Int64Attribute{
+				MarkdownDescription: "iTunes developer (artist) ID.",
+				Computed:            true,
+			},
+			"artist_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the developer.",
+				Computed:            true,
+			},
+			"release_notes": schema.StringAttribute{
+				MarkdownDescription: "Release notes for the current version.",
+				Computed:            true,
+			},
+			"release_date": schema.StringAttribute{
+				MarkdownDescription: "Original release date.",
+				Computed:            true,
+			},
+			"current_version_release_date": schema.StringAttribute{
+				MarkdownDescription: "Release date of the current version.",
+				Computed:            true,
+			},
+			"price": schema.Float64Attribute{
+				MarkdownDescription: "Price.",
+				Computed:            true,
+			},
+			"formatted_price": schema.StringAttribute{
+				MarkdownDescription: "Formatted price string.",
+				Computed:            true,
+			},
+			"currency": schema.StringAttribute{
+				MarkdownDescription: "Currency code.",
+				Computed:            true,
+			},
+			"primary_genre": schema.StringAttribute{
+				MarkdownDescription: "Primary genre.",
+				Computed:            true,
+			},
+			"genres": schema.ListAttribute{
+				MarkdownDescription: "List of genres.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"content_advisory_rating": schema.StringAttribute{
+				MarkdownDescription: "Content advisory rating (e.g., 4+, 12+).",
+				Computed:            true,
+			},
+			"track_view_url": schema.StringAttribute{
+				MarkdownDescription: "URL to the App Store page.",
+				Computed:            true,
+			},
+			"artwork_url": schema.StringAttribute{
+				MarkdownDescription: "URL to 512x512 artwork.",
+				Computed:            true,
+			},
+			"screenshot_urls": schema.ListAttribute{
+				MarkdownDescription: "List of iPhone screenshot URLs.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ipad_screenshot_urls": schema.ListAttribute{
+				MarkdownDescription: "List of iPad screenshot URLs.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"appletv_screenshot_urls": schema.ListAttribute{
+				MarkdownDescription: "List of Apple TV screenshot URLs.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"supported_devices": schema.ListAttribute{
+				MarkdownDescription: "List of supported devices.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"languages": schema.ListAttribute{
+				MarkdownDescription: "List of supported languages.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"average_rating": schema.Float64Attribute{
+				MarkdownDescription: "Average user rating.",
+				Computed:            true,
+			},
+			"rating_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of ratings.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure sets up the data source with the provider-configured client.
+func (d *AppDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+// Read looks up a single app using the configured selector and maps it to state.
+func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout := common.DefaultReadTimeout
+	if !data.Timeouts.IsNull() && !data.Timeouts.IsUnknown() {
+		configuredTimeout, timeoutDiags := data.Timeouts.Read(ctx, common.DefaultReadTimeout)
+		resp.Diagnostics.Append(timeoutDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		readTimeout = configuredTimeout
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	lookupReq, diags := buildAppLookupRequest(data, d.client.DefaultCountry())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diags := lookupApp(readCtx, d.client, lookupReq)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapAppToModel(ctx, result, &data)
+	data.Country = types.StringValue(lookupReq.Country)
+
+	resp.Diagnostics.Append(checkVersionConstraints(data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "App data source read", map[string]any{
+		"track_id":  data.TrackID.ValueInt64(),
+		"bundle_id": data.BundleID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,111 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package app_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/provider"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/acctest"
+)
+
+// providerFactories returns a map of provider factories for acceptance tests.
+var providerFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"itunessearchapi": providerserver.NewProtocol6WithError(provider.New("test")()),
+}
+
+func TestAccAppDataSource_BundleID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "itunessearchapi_app" "test" {
+  bundle_id = "com.apple.Pages"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.itunessearchapi_app.test", "bundle_id", "com.apple.Pages"),
+					resource.TestCheckResourceAttrSet("data.itunessearchapi_app.test", "track_id"),
+					resource.TestCheckResourceAttrSet("data.itunessearchapi_app.test", "version"),
+					resource.TestCheckResourceAttrSet("data.itunessearchapi_app.test", "minimum_os_version"),
+					resource.TestCheckResourceAttrSet("data.itunessearchapi_app.test", "current_version_release_date"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAppDataSource_AppStoreURL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "itunessearchapi_app" "test" {
+  app_store_url = "https://apps.apple.com/gb/app/pages/id361309726"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.itunessearchapi_app.test", "track_id", "361309726"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app.test", "country", "gb"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app.test", "bundle_id", "com.apple.Pages"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAppDataSource_NotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "itunessearchapi_app" "test" {
+  bundle_id = "com.example.does.not.exist"
+}
+`,
+				ExpectError: regexp.MustCompile(`App Not Found`),
+			},
+		},
+	})
+}
+
+func TestAccAppDataSource_VersionConstraint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "itunessearchapi_app" "test" {
+  bundle_id       = "com.apple.Pages"
+  minimum_version = "1.0"
+}
+`,
+				Check: resource.TestCheckResourceAttrSet("data.itunessearchapi_app.test", "version"),
+			},
+			{
+				Config: `
+data "itunessearchapi_app" "test" {
+  bundle_id       = "com.apple.Pages"
+  minimum_version = "9999.0"
+}
+`,
+				ExpectError: regexp.MustCompile(`Version Constraint Not Met`),
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestAppDataSource_Metadata(t *testing.T) {
+	ds := &AppDataSource{}
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "itunessearchapi",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	expected := "itunessearchapi_app"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name %q, got %q", expected, resp.TypeName)
+	}
+}
+
+func TestAppDataSource_Schema(t *testing.T) {
+	ds := &AppDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Attributes == nil {
+		t.Fatal("expected non-nil schema attributes")
+	}
+
+	requiredAttrs := []string{
+		"timeouts", "bundle_id", "track_id", "app_store_url", "country",
+		"minimum_version", "exact_version", "supported_os_version",
+		"name", "version", "minimum_os_version", "file_size_bytes",
+		"seller_name", "screenshot_urls", "release_notes",
+		"current_version_release_date",
+	}
+
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected schema to contain attribute %q", attr)
+		}
+	}
+}
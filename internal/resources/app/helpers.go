@@ -0,0 +1,215 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/diagnostics"
+)
+
+// buildAppLookupRequest creates a lookup request for the selector set in the
+// data model, querying defaultCountry when no storefront is given.
+func buildAppLookupRequest(data AppDataSourceModel, defaultCountry string) (client.LookupRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	req := client.LookupRequest{
+		Country: common.StringValue(data.Country),
+		Limit:   1,
+	}
+
+	switch {
+	case !data.AppStoreURL.IsNull() && !data.AppStoreURL.IsUnknown():
+		parsed, err := common.ParseAppleURL(data.AppStoreURL.ValueString(), defaultCountry)
+		if err != nil {
+			diags.AddError("Invalid App Store URL", err.Error())
+			return req, diags
+		}
+		if !parsed.IsApp() {
+			diags.AddError("Invalid App Store URL", fmt.Sprintf("%q links to a %s, not an app.", data.AppStoreURL.ValueString(), parsed.Kind))
+			return req, diags
+		}
+		req.IDs = []int64{parsed.ID}
+		req.Country = parsed.Country
+		req.Media, req.Entity = parsed.Media, parsed.Entity
+
+	case !data.TrackID.IsNull() && !data.TrackID.IsUnknown():
+		req.IDs = []int64{data.TrackID.ValueInt64()}
+
+	case !data.BundleID.IsNull() && !data.BundleID.IsUnknown():
+		req.BundleIDs = []string{data.BundleID.ValueString()}
+
+	default:
+		diags.AddError("No Selector", "One of bundle_id, track_id, or app_store_url must be set.")
+		return req, diags
+	}
+
+	if req.Country == "" {
+		req.Country = defaultCountry
+	}
+
+	return req, diags
+}
+
+// describeSelector returns a human-readable description of the lookup selector.
+func describeSelector(req client.LookupRequest) string {
+	if len(req.BundleIDs) > 0 {
+		return fmt.Sprintf("bundle ID %q", req.BundleIDs[0])
+	}
+	return fmt.Sprintf("track ID %d", req.IDs[0])
+}
+
+// lookupApp performs the lookup and returns the single matching app, reporting
+// a clear diagnostic when the app cannot be found in the storefront.
+func lookupApp(ctx context.Context, c *client.Client, req client.LookupRequest) (client.ContentResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result, err := c.Lookup(ctx, req)
+	if err != nil {
+		var notFoundErr *client.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			diags.AddError(diagnostics.APIError(err))
+			return client.ContentResult{}, diags
+		}
+		result = nil
+	}
+
+	if result != nil {
+		for _, item := range result.Results {
+			if len(req.BundleIDs) > 0 && !strings.EqualFold(item.BundleID, req.BundleIDs[0]) {
+				continue
+			}
+			if len(req.IDs) > 0 && item.TrackID != req.IDs[0] {
+				continue
+			}
+			return item, diags
+		}
+	}
+
+	diags.AddError(
+		"App Not Found",
+		fmt.Sprintf("No app with %s was found in the %q App Store storefront. Check the identifier and country.", describeSelector(req), req.Country),
+	)
+	return client.ContentResult{}, diags
+}
+
+// mapAppToModel copies the app result into the data source model.
+func mapAppToModel(ctx context.Context, result client.ContentResult, data *AppDataSourceModel) {
+	data.BundleID = types.StringValue(result.BundleID)
+	data.TrackID = types.Int64Value(result.TrackID)
+	data.Name = types.StringValue(result.TrackName)
+	data.Description = types.StringValue(result.Description)
+	data.Kind = types.StringValue(result.Kind)
+	data.Version = types.StringValue(result.Version)
+	data.MinimumOSVersion = types.StringValue(result.MinimumOSVersion)
+	data.SellerName = types.StringValue(result.SellerName)
+	data.SellerURL = types.StringValue(result.SellerURL)
+	data.ArtistID = types.Int64Value(result.ArtistID)
+	data.ArtistName = types.StringValue(result.ArtistName)
+	data.ReleaseNotes = types.StringValue(result.ReleaseNotes)
+	data.ReleaseDate = types.StringValue(result.ReleaseDate)
+	data.CurrentVersionReleaseDate = types.StringValue(result.CurrentVersionReleaseDate)
+	data.Price = types.Float64Value(result.Price)
+	data.FormattedPrice = types.StringValue(result.FormattedPrice)
+	data.Currency = types.StringValue(result.Currency)
+	data.PrimaryGenre = types.StringValue(result.PrimaryGenre)
+	data.Genres = stringValues(result.Genres)
+	data.ContentAdvisoryRating = types.StringValue(result.ContentAdvisoryRating)
+	data.TrackViewURL = types.StringValue(result.TrackViewURL)
+	data.ArtworkURL = types.StringValue(result.ArtworkURL)
+	data.ScreenshotURLs = stringValues(result.ScreenshotURLs)
+	data.IPadScreenshotURLs = stringValues(result.IPadScreenshotURLs)
+	data.AppleTVScreenshotURLs = stringValues(result.AppleTVScreenshotURLs)
+	data.SupportedDevices = stringValues(result.SupportedDevices)
+	data.Languages = stringValues(result.Languages)
+	data.AverageRating = types.Float64Value(result.AverageRating)
+	data.RatingCount = types.Int64Value(result.RatingCount)
+
+	data.FileSizeBytes = types.Int64Null()
+	if result.FileSizeBytes != "" {
+		size, err := strconv.ParseInt(result.FileSizeBytes, 10, 64)
+		if err != nil {
+			tflog.Warn(ctx, "Failed to parse app file size", map[string]any{
+				"file_size_bytes": result.FileSizeBytes,
+				"error":           err.Error(),
+			})
+		} else {
+			data.FileSizeBytes = types.Int64Value(size)
+		}
+	}
+}
+
+// checkVersionConstraints reports an error for each version constraint in the
+// data model that the looked-up app does not meet.
+func checkVersionConstraints(data AppDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	app := fmt.Sprintf("%s (%s)", data.Name.ValueString(), data.BundleID.ValueString())
+	storefront := data.Country.ValueString()
+
+	if v := common.StringValue(data.MinimumVersion); v != "" {
+		if c, err := compareVersions(&diags, "minimum_version", data.Version.ValueString(), v); err == nil && c < 0 {
+			diags.AddAttributeError(
+				path.Root("minimum_version"),
+				"Version Constraint Not Met",
+				fmt.Sprintf("%s is at version %s in the %q App Store storefront, but minimum_version requires %s or newer.", app, data.Version.ValueString(), storefront, v),
+			)
+		}
+	}
+
+	if v := common.StringValue(data.ExactVersion); v != "" {
+		if c, err := compareVersions(&diags, "exact_version", data.Version.ValueString(), v); err == nil && c != 0 {
+			diags.AddAttributeError(
+				path.Root("exact_version"),
+				"Version Constraint Not Met",
+				fmt.Sprintf("%s is at version %s in the %q App Store storefront, but exact_version requires %s.", app, data.Version.ValueString(), storefront, v),
+			)
+		}
+	}
+
+	if v := common.StringValue(data.SupportedOSVersion); v != "" {
+		if c, err := compareVersions(&diags, "supported_os_version", data.MinimumOSVersion.ValueString(), v); err == nil && c > 0 {
+			diags.AddAttributeError(
+				path.Root("supported_os_version"),
+				"Version Constraint Not Met",
+				fmt.Sprintf("%s requires OS version %s or newer in the %q App Store storefront, but supported_os_version is %s.", app, data.MinimumOSVersion.ValueString(), storefront, v),
+			)
+		}
+	}
+
+	return diags
+}
+
+// compareVersions compares the app's reported version against a constraint,
+// recording a diagnostic when either cannot be parsed.
+func compareVersions(diags *diag.Diagnostics, attr, actual, constraint string) (int, error) {
+	c, err := common.CompareVersions(actual, constraint)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Version Comparison Failed",
+			fmt.Sprintf("Unable to compare the App Store version with %s: %s", attr, err),
+		)
+	}
+	return c, err
+}
+
+// stringValues converts a slice of strings to Terraform string values.
+func stringValues(values []string) []types.String {
+	out := make([]types.String, len(values))
+	for i, v := range values {
+		out[i] = types.StringValue(v)
+	}
+	return out
+}
@@ -0,0 +1,279 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
+
+func nullAppModel() AppDataSourceModel {
+	return AppDataSourceModel{
+		BundleID:    types.StringNull(),
+		TrackID:     types.Int64Null(),
+		AppStoreURL: types.StringNull(),
+		Country:     types.StringNull(),
+	}
+}
+
+func TestBuildAppLookupRequest_BundleID(t *testing.T) {
+	data := nullAppModel()
+	data.BundleID = types.StringValue("com.apple.Pages")
+
+	req, diags := buildAppLookupRequest(data, common.DefaultCountry)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(req.BundleIDs) != 1 || req.BundleIDs[0] != "com.apple.Pages" {
+		t.Errorf("unexpected bundle IDs: %v", req.BundleIDs)
+	}
+	if req.Country != common.DefaultCountry {
+		t.Errorf("expected default country %q, got %q", common.DefaultCountry, req.Country)
+	}
+}
+
+func TestBuildAppLookupRequest_AppStoreURL(t *testing.T) {
+	data := nullAppModel()
+	data.AppStoreURL = types.StringValue("https://apps.apple.com/gb/app/pages/id361309726")
+
+	req, diags := buildAppLookupRequest(data, common.DefaultCountry)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(req.IDs) != 1 || req.IDs[0] != 361309726 {
+		t.Errorf("unexpected IDs: %v", req.IDs)
+	}
+	if req.Country != "gb" {
+		t.Errorf("expected country %q, got %q", "gb", req.Country)
+	}
+	if req.Media != "software" || req.Entity != "software" {
+		t.Errorf("expected media and entity software, got %q and %q", req.Media, req.Entity)
+	}
+}
+
+func TestBuildAppLookupRequest_URLWithoutCountry(t *testing.T) {
+	data := nullAppModel()
+	data.AppStoreURL = types.StringValue("https://itunes.apple.com/app/id361309726?mt=8")
+
+	req, diags := buildAppLookupRequest(data, "de")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(req.IDs) != 1 || req.IDs[0] != 361309726 {
+		t.Errorf("unexpected IDs: %v", req.IDs)
+	}
+	if req.Country != "de" {
+		t.Errorf("expected default country %q, got %q", "de", req.Country)
+	}
+}
+
+func TestBuildAppLookupRequest_NonAppURL(t *testing.T) {
+	data := nullAppModel()
+	data.AppStoreURL = types.StringValue("https://music.apple.com/us/album/in-between-dreams/1469577723")
+
+	_, diags := buildAppLookupRequest(data, common.DefaultCountry)
+	if !diags.HasError() {
+		t.Fatal("expected error for a non-app URL")
+	}
+}
+
+func TestBuildAppLookupRequest_NoSelector(t *testing.T) {
+	_, diags := buildAppLookupRequest(nullAppModel(), common.DefaultCountry)
+	if !diags.HasError() {
+		t.Fatal("expected error when no selector is set")
+	}
+}
+
+func TestLookupApp_Found(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, `{"results":[{"trackId":361309726,"bundleId":"com.apple.Pages","trackName":"Pages","version":"14.0","fileSizeBytes":"123456"}]}`)
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	result, diags := lookupApp(context.Background(), c, client.LookupRequest{BundleIDs: []string{"com.apple.Pages"}, Country: "us"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	data := nullAppModel()
+	mapAppToModel(context.Background(), result, &data)
+	if data.Name.ValueString() != "Pages" || data.Version.ValueString() != "14.0" {
+		t.Errorf("unexpected app: %q %q", data.Name.ValueString(), data.Version.ValueString())
+	}
+	if data.FileSizeBytes.ValueInt64() != 123456 {
+		t.Errorf("expected file size 123456, got %d", data.FileSizeBytes.ValueInt64())
+	}
+}
+
+func TestLookupApp_BundleIDCaseInsensitive(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	result, diags := lookupApp(context.Background(), c, client.LookupRequest{BundleIDs: []string{"COM.APPLE.pages"}, Country: "us"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if result.BundleID != "com.apple.Pages" {
+		t.Errorf("expected bundle ID %q, got %q", "com.apple.Pages", result.BundleID)
+	}
+}
+
+func TestLookupApp_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, `{"resultCount":0,"results":[]}`)
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	_, diags := lookupApp(context.Background(), c, client.LookupRequest{IDs: []int64{1}, Country: "us"})
+	if !diags.HasError() {
+		t.Fatal("expected App Not Found error")
+	}
+	if diags[0].Summary() != "App Not Found" {
+		t.Errorf("expected summary %q, got %q", "App Not Found", diags[0].Summary())
+	}
+}
+
+func TestLookupApp_FakeServer(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	data := nullAppModel()
+	data.TrackID = types.Int64Value(361285480)
+	req, diags := buildAppLookupRequest(data, common.DefaultCountry)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	result, diags := lookupApp(context.Background(), c, req)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	mapAppToModel(context.Background(), result, &data)
+	if data.BundleID.ValueString() != "com.apple.Keynote" {
+		t.Errorf("expected bundle ID %q, got %q", "com.apple.Keynote", data.BundleID.ValueString())
+	}
+	if got := server.Requests()[0].Query().Get("id"); got != "361285480" {
+		t.Errorf("expected id query 361285480, got %q", got)
+	}
+}
+
+func TestLookupApp_MacAppStoreURLSendsEntity(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	data := nullAppModel()
+	data.AppStoreURL = types.StringValue("https://apps.apple.com/us/app/keynote/id361285480?mt=12")
+	req, diags := buildAppLookupRequest(data, common.DefaultCountry)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	if _, diags := lookupApp(context.Background(), c, req); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	query := server.Requests()[0].Query()
+	if query.Get("media") != "software" || query.Get("entity") != "macSoftware" {
+		t.Errorf("expected media=software and entity=macSoftware, got %s", query.Encode())
+	}
+}
+
+func versionedAppModel() AppDataSourceModel {
+	data := nullAppModel()
+	data.MinimumVersion = types.StringNull()
+	data.ExactVersion = types.StringNull()
+	data.SupportedOSVersion = types.StringNull()
+	data.Name = types.StringValue("Pages")
+	data.BundleID = types.StringValue("com.apple.Pages")
+	data.Country = types.StringValue("us")
+	data.Version = types.StringValue("14.10")
+	data.MinimumOSVersion = types.StringValue("17.0")
+	return data
+}
+
+func TestCheckVersionConstraints_Met(t *testing.T) {
+	data := versionedAppModel()
+	data.MinimumVersion = types.StringValue("14.9")
+	data.SupportedOSVersion = types.StringValue("17")
+
+	if diags := checkVersionConstraints(data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	data = versionedAppModel()
+	data.ExactVersion = types.StringValue("14.10.0")
+	if diags := checkVersionConstraints(data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestCheckVersionConstraints_NotMet(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      func(*AppDataSourceModel)
+		expected string
+	}{
+		{
+			name:     "minimum_version",
+			set:      func(d *AppDataSourceModel) { d.MinimumVersion = types.StringValue("15.0") },
+			expected: `Pages (com.apple.Pages) is at version 14.10 in the "us" App Store storefront, but minimum_version requires 15.0 or newer.`,
+		},
+		{
+			name:     "exact_version",
+			set:      func(d *AppDataSourceModel) { d.ExactVersion = types.StringValue("14.1") },
+			expected: `Pages (com.apple.Pages) is at version 14.10 in the "us" App Store storefront, but exact_version requires 14.1.`,
+		},
+		{
+			name:     "supported_os_version",
+			set:      func(d *AppDataSourceModel) { d.SupportedOSVersion = types.StringValue("16.7.2") },
+			expected: `Pages (com.apple.Pages) requires OS version 17.0 or newer in the "us" App Store storefront, but supported_os_version is 16.7.2.`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := versionedAppModel()
+			tt.set(&data)
+
+			diags := checkVersionConstraints(data)
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			if diags[0].Summary() != "Version Constraint Not Met" {
+				t.Errorf("unexpected summary %q", diags[0].Summary())
+			}
+			if diags[0].Detail() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, diags[0].Detail())
+			}
+		})
+	}
+}
+
+func TestCheckVersionConstraints_UnparseableVersion(t *testing.T) {
+	data := versionedAppModel()
+	data.Version = types.StringValue("")
+	data.MinimumVersion = types.StringValue("1.0")
+
+	diags := checkVersionConstraints(data)
+	if !diags.HasError() || diags[0].Summary() != "Version Comparison Failed" {
+		t.Fatalf("expected Version Comparison Failed error, got %v", diags)
+	}
+}
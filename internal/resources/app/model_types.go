@@ -0,0 +1,49 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package app
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AppDataSourceModel describes the app data source data model.
+type AppDataSourceModel struct {
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+	BundleID                  types.String   `tfsdk:"bundle_id"`
+	TrackID                   types.Int64    `tfsdk:"track_id"`
+	AppStoreURL               types.String   `tfsdk:"app_store_url"`
+	Country                   types.String   `tfsdk:"country"`
+	MinimumVersion            types.String   `tfsdk:"minimum_version"`
+	ExactVersion              types.String   `tfsdk:"exact_version"`
+	SupportedOSVersion        types.String   `tfsdk:"supported_os_version"`
+	Name                      types.String   `tfsdk:"name"`
+	Description               types.String   `tfsdk:"description"`
+	Kind                      types.String   `tfsdk:"kind"`
+	Version                   types.String   `tfsdk:"version"`
+	MinimumOSVersion          types.String   `tfsdk:"minimum_os_version"`
+	FileSizeBytes             types.Int64    `tfsdk:"file_size_bytes"`
+	SellerName                types.String   `tfsdk:"seller_name"`
+	SellerURL                 types.String   `tfsdk:"seller_url"`
+	ArtistID                  types.Int64    `tfsdk:"artist_id"`
+	ArtistName                types.String   `tfsdk:"artist_name"`
+	ReleaseNotes              types.String   `tfsdk:"release_notes"`
+	ReleaseDate               types.String   `tfsdk:"release_date"`
+	CurrentVersionReleaseDate types.String   `tfsdk:"current_version_release_date"`
+	Price                     types.Float64  `tfsdk:"price"`
+	FormattedPrice            types.String   `tfsdk:"formatted_price"`
+	Currency                  types.String   `tfsdk:"currency"`
+	PrimaryGenre              types.String   `tfsdk:"primary_genre"`
+	Genres                    []types.String `tfsdk:"genres"`
+	ContentAdvisoryRating     types.String   `tfsdk:"content_advisory_rating"`
+	TrackViewURL              types.String   `tfsdk:"track_view_url"`
+	ArtworkURL                types.String   `tfsdk:"artwork_url"`
+	ScreenshotURLs            []types.String `tfsdk:"screenshot_urls"`
+	IPadScreenshotURLs        []types.String `tfsdk:"ipad_screenshot_urls"`
+	AppleTVScreenshotURLs     []types.String `tfsdk:"appletv_screenshot_urls"`
+	SupportedDevices          []types.String `tfsdk:"supported_devices"`
+	Languages                 []types.String `tfsdk:"languages"`
+	AverageRating             types.Float64  `tfsdk:"average_rating"`
+	RatingCount               types.Int64    `tfsdk:"rating_count"`
+}
@@ -0,0 +1,193 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package appavailability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+)
+
+var _ datasource.DataSource = &AppAvailabilityDataSource{}
+
+// AppAvailabilityDataSource defines the data source implementation.
+type AppAvailabilityDataSource struct {
+	client *client.Client
+}
+
+// NewAppAvailabilityDataSource returns a new instance of the app availability data source.
+func NewAppAvailabilityDataSource() datasource.DataSource {
+	return &AppAvailabilityDataSource{}
+}
+
+// Metadata sets the data source type name.
+func (d *AppAvailabilityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_availability"
+}
+
+// Schema defines the data source schema.
+func (d *AppAvailabilityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Check which App Store storefronts list each of a set of apps, with the version and price in each storefront.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx),
+			"bundle_ids": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Bundle IDs of the apps to check.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"countries": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "ISO 2-letter country codes (lowercase) of the storefronts to check. One lookup is made per storefront.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(common.CountryCodeRegex, "must be a valid ISO 3166-1 alpha-2 country code"),
+					),
+				},
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "Availability of each app, in the order of `bundle_ids`. Apps that no storefront lists are returned with empty `available_in` rather than failing the read.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bundle_id": schema.StringAttribute{
+							MarkdownDescription: "Requested bundle ID.",
+							Computed:            true,
+						},
+						"track_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes track ID of the app. Null when no storefront lists the app.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the app in the first storefront that lists it. Null when no storefront lists the app.",
+							Computed:            true,
+						},
+						"available_everywhere": schema.BoolAttribute{
+							MarkdownDescription: "Whether every requested storefront lists the app.",
+							Computed:            true,
+						},
+						"available_in": schema.ListAttribute{
+							MarkdownDescription: "Storefronts that list the app.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"missing_in": schema.ListAttribute{
+							MarkdownDescription: "Storefronts that do not list the app.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"storefronts": schema.MapNestedAttribute{
+							MarkdownDescription: "Listing details keyed by storefront code, for the storefronts that list the app.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"version": schema.StringAttribute{
+										MarkdownDescription: "Version available in the storefront.",
+										Computed:            true,
+									},
+									"price": schema.Float64Attribute{
+										MarkdownDescription: "Price in the storefront's currency.",
+										Computed:            true,
+									},
+									"formatted_price": schema.StringAttribute{
+										MarkdownDescription: "Formatted price string.",
+										Computed:            true,
+									},
+									"currency": schema.StringAttribute{
+										MarkdownDescription: "Currency code.",
+										Computed:            true,
+									},
+									"track_view_url": schema.StringAttribute{
+										MarkdownDescription: "URL to the App Store page in the storefront.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure sets up the data source with the provider-configured client.
+func (d *AppAvailabilityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+// Read looks up the apps in every requested storefront and maps their availability to state.
+func (d *AppAvailabilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppAvailabilityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout := common.DefaultReadTimeout
+	if !data.Timeouts.IsNull() && !data.Timeouts.IsUnknown() {
+		configuredTimeout, timeoutDiags := data.Timeouts.Read(ctx, common.DefaultReadTimeout)
+		resp.Diagnostics.Append(timeoutDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		readTimeout = configuredTimeout
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var bundleIDs, countries []string
+	resp.Diagnostics.Append(data.BundleIDs.ElementsAs(ctx, &bundleIDs, false)...)
+	resp.Diagnostics.Append(data.Countries.ElementsAs(ctx, &countries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apps, diags := lookupAvailability(readCtx, d.client, bundleIDs, countries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Apps = apps
+
+	tflog.Debug(ctx, "App availability data source read", map[string]any{
+		"app_count":     len(bundleIDs),
+		"country_count": len(countries),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
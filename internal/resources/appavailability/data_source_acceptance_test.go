@@ -0,0 +1,47 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+
+package appavailability_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/provider"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/acctest"
+)
+
+// providerFactories returns a map of provider factories for acceptance tests.
+var providerFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"itunessearchapi": providerserver.NewProtocol6WithError(provider.New("test")()),
+}
+
+func TestAccAppAvailabilityDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "itunessearchapi_app_availability" "test" {
+  bundle_ids = ["com.apple.Pages", "com.example.does.not.exist"]
+  countries  = ["us", "gb"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.itunessearchapi_app_availability.test", "apps.#", "2"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app_availability.test", "apps.0.track_id", "361309726"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app_availability.test", "apps.0.available_everywhere", "true"),
+					resource.TestCheckResourceAttrSet("data.itunessearchapi_app_availability.test", "apps.0.storefronts.gb.currency"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app_availability.test", "apps.1.available_everywhere", "false"),
+					resource.TestCheckResourceAttr("data.itunessearchapi_app_availability.test", "apps.1.missing_in.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package appavailability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestAppAvailabilityDataSource_Metadata(t *testing.T) {
+	ds := &AppAvailabilityDataSource{}
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "itunessearchapi",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	expected := "itunessearchapi_app_availability"
+	if resp.TypeName != expected {
+		t.Errorf("expected type name %q, got %q", expected, resp.TypeName)
+	}
+}
+
+func TestAppAvailabilityDataSource_Schema(t *testing.T) {
+	ds := &AppAvailabilityDataSource{}
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Attributes == nil {
+		t.Fatal("expected non-nil schema attributes")
+	}
+
+	requiredAttrs := []string{
+		"timeouts", "bundle_ids", "countries", "apps",
+	}
+
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("expected schema to contain attribute %q", attr)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package appavailability
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/diagnostics"
+)
+
+// lookupAvailability looks up the bundle IDs in each storefront and returns
+// the availability of every app, in the order the bundle IDs were requested.
+// Apps that a storefront does not return are reported as missing there rather
+// than as an error.
+func lookupAvailability(ctx context.Context, c *client.Client, bundleIDs, countries []string) ([]AppAvailabilityModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	found := make(map[string]map[string]client.ContentResult, len(countries))
+	for _, country := range countries {
+		found[country] = make(map[string]client.ContentResult, len(bundleIDs))
+		for _, batch := range common.ChunkStrings(bundleIDs, common.MaxLookupBatchSize) {
+			result, err := c.Lookup(ctx, client.LookupRequest{BundleIDs: batch, Country: country})
+			if err != nil {
+				var notFoundErr *client.NotFoundError
+				if !errors.As(err, &notFoundErr) {
+					diags.AddError(diagnostics.APIError(err))
+					return nil, diags
+				}
+			}
+			for _, item := range result.Results {
+				if item.BundleID != "" {
+					found[country][strings.ToLower(item.BundleID)] = item
+				}
+			}
+		}
+	}
+
+	return buildAvailability(bundleIDs, countries, found), diags
+}
+
+// buildAvailability builds one availability entry per bundle ID from the apps
+// found in each storefront, keyed by lowercased bundle ID.
+func buildAvailability(bundleIDs, countries []string, found map[string]map[string]client.ContentResult) []AppAvailabilityModel {
+	apps := make([]AppAvailabilityModel, 0, len(bundleIDs))
+	for _, bundleID := range bundleIDs {
+		app := AppAvailabilityModel{
+			BundleID:    types.StringValue(bundleID),
+			TrackID:     types.Int64Null(),
+			Name:        types.StringNull(),
+			AvailableIn: []types.String{},
+			MissingIn:   []types.String{},
+			Storefronts: make(map[string]StorefrontModel, len(countries)),
+		}
+
+		for _, country := range countries {
+			item, ok := found[country][strings.ToLower(bundleID)]
+			if !ok {
+				app.MissingIn = append(app.MissingIn, types.StringValue(country))
+				continue
+			}
+
+			if app.TrackID.IsNull() {
+				app.TrackID = types.Int64Value(item.TrackID)
+				app.Name = types.StringValue(item.TrackName)
+			}
+			app.AvailableIn = append(app.AvailableIn, types.StringValue(country))
+			app.Storefronts[country] = StorefrontModel{
+				Version:        types.StringValue(item.Version),
+				Price:          types.Float64Value(item.Price),
+				FormattedPrice: types.StringValue(item.FormattedPrice),
+				Currency:       types.StringValue(item.Currency),
+				TrackViewURL:   types.StringValue(item.TrackViewURL),
+			}
+		}
+
+		app.AvailableEverywhere = types.BoolValue(len(app.MissingIn) == 0)
+		apps = append(apps, app)
+	}
+	return apps
+}
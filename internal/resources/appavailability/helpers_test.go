@@ -0,0 +1,103 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package appavailability
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
+
+func newAvailabilityServer() *fakeitunes.Server {
+	return fakeitunes.New(
+		fakeitunes.Item{
+			WrapperType: "software",
+			TrackID:     1,
+			TrackName:   "Regional",
+			BundleID:    "com.example.Regional",
+			Countries:   []string{"us", "gb"},
+			Fields:      map[string]any{"version": "2.1", "price": 0.99, "formattedPrice": "$0.99", "currency": "USD"},
+		},
+		fakeitunes.Item{
+			WrapperType: "software",
+			TrackID:     2,
+			TrackName:   "Global",
+			BundleID:    "com.example.global",
+			Fields:      map[string]any{"version": "1.0", "price": 0.0, "formattedPrice": "Free", "currency": "USD"},
+		},
+	)
+}
+
+func TestLookupAvailability(t *testing.T) {
+	server := newAvailabilityServer()
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	apps, diags := lookupAvailability(context.Background(), c,
+		[]string{"com.example.regional", "com.example.global", "com.example.delisted"},
+		[]string{"us", "gb", "jp"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(apps) != 3 {
+		t.Fatalf("expected 3 apps, got %d", len(apps))
+	}
+	if server.RequestCount() != 3 {
+		t.Errorf("expected one request per storefront, got %d", server.RequestCount())
+	}
+
+	regional := apps[0]
+	if regional.BundleID.ValueString() != "com.example.regional" {
+		t.Errorf("expected requested bundle ID to be preserved, got %s", regional.BundleID)
+	}
+	if regional.TrackID.ValueInt64() != 1 || regional.Name.ValueString() != "Regional" {
+		t.Errorf("unexpected app identity: %s %s", regional.TrackID, regional.Name)
+	}
+	if regional.AvailableEverywhere.ValueBool() {
+		t.Error("expected regional app not to be available everywhere")
+	}
+	if len(regional.AvailableIn) != 2 || len(regional.MissingIn) != 1 || regional.MissingIn[0].ValueString() != "jp" {
+		t.Errorf("unexpected availability: in=%v missing=%v", regional.AvailableIn, regional.MissingIn)
+	}
+	gb, ok := regional.Storefronts["gb"]
+	if !ok {
+		t.Fatal("expected gb storefront details")
+	}
+	if gb.Version.ValueString() != "2.1" || gb.Price.ValueFloat64() != 0.99 || gb.Currency.ValueString() != "USD" {
+		t.Errorf("unexpected storefront details: %+v", gb)
+	}
+	if _, ok := regional.Storefronts["jp"]; ok {
+		t.Error("expected no storefront details where the app is missing")
+	}
+
+	if !apps[1].AvailableEverywhere.ValueBool() || len(apps[1].Storefronts) != 3 {
+		t.Errorf("expected global app in every storefront, got %+v", apps[1])
+	}
+
+	delisted := apps[2]
+	if !delisted.TrackID.IsNull() || !delisted.Name.IsNull() {
+		t.Errorf("expected null identity for an app no storefront lists, got %s %s", delisted.TrackID, delisted.Name)
+	}
+	if len(delisted.AvailableIn) != 0 || len(delisted.MissingIn) != 3 {
+		t.Errorf("unexpected availability: in=%v missing=%v", delisted.AvailableIn, delisted.MissingIn)
+	}
+}
+
+func TestLookupAvailability_APIError(t *testing.T) {
+	server := newAvailabilityServer()
+	defer server.Close()
+	server.FailWithStatus(1, http.StatusBadRequest)
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	_, diags := lookupAvailability(context.Background(), c, []string{"com.example.global"}, []string{"us"})
+	if !diags.HasError() {
+		t.Fatal("expected API Request Rejected error")
+	}
+	if diags[0].Summary() != "API Request Rejected" {
+		t.Errorf("unexpected summary %q", diags[0].Summary())
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package appavailability
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AppAvailabilityDataSourceModel describes the app availability data source data model.
+type AppAvailabilityDataSourceModel struct {
+	Timeouts  timeouts.Value         `tfsdk:"timeouts"`
+	BundleIDs types.List             `tfsdk:"bundle_ids"`
+	Countries types.List             `tfsdk:"countries"`
+	Apps      []AppAvailabilityModel `tfsdk:"apps"`
+}
+
+// AppAvailabilityModel describes where a single requested app is available.
+type AppAvailabilityModel struct {
+	BundleID            types.String               `tfsdk:"bundle_id"`
+	TrackID             types.Int64                `tfsdk:"track_id"`
+	Name                types.String               `tfsdk:"name"`
+	AvailableEverywhere types.Bool                 `tfsdk:"available_everywhere"`
+	AvailableIn         []types.String             `tfsdk:"available_in"`
+	MissingIn           []types.String             `tfsdk:"missing_in"`
+	Storefronts         map[string]StorefrontModel `tfsdk:"storefronts"`
+}
+
+// StorefrontModel describes an app as listed in a single storefront.
+type StorefrontModel struct {
+	Version        types.String  `tfsdk:"version"`
+	Price          types.Float64 `tfsdk:"price"`
+	FormattedPrice types.String  `tfsdk:"formatted_price"`
+	Currency       types.String  `tfsdk:"currency"`
+	TrackViewURL   types.String  `tfsdk:"track_view_url"`
+}
@@ -49,7 +49,7 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			"app_store_urls": schema.ListAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of App Store URLs. Mutually exclusive with all other selectors.",
+				MarkdownDescription: "List of App Store URLs, or other Apple media URLs on the itunes, music, podcasts, books, or tv.apple.com hosts. Each URL is looked up in the storefront it names, or the provider's `default_country` when it has none. Album and podcast URLs with an `i` query parameter select that track or episode. Unless `entity` is set, each URL is looked up with the media and entity inferred from its host and path, for example `music` and `album` for an album URL. Mutually exclusive with all other selectors.",
 				Validators: []validator.List{
 					listvalidator.ExactlyOneOf(
 						path.MatchRelative().AtParent().AtName("term"),
@@ -62,8 +62,7 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 						path.MatchRelative().AtParent().AtName("bundle_ids"),
 					),
 					listvalidator.ValueStringsAre(
-						stringvalidator.RegexMatches(regexp.MustCompile(`^https://apps\.apple\.com/([a-z]{2})/.*?/id(\d+)`),
-							"Each URL must be a valid App Store URL in the format: https://apps.apple.com/{country}/app/{app-name}/id{app-id}"),
+						common.AppleURLValidator(),
 					),
 				},
 			},
@@ -224,11 +223,28 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(
 						path.MatchRoot("app_store_urls"),
+						path.MatchRoot("countries"),
 					),
 					stringvalidator.LengthBetween(2, 2),
 					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}$`), "must be a valid ISO 3166-1 alpha-2 country code"),
 				},
 			},
+			"countries": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "ISO 2-letter country codes (lowercase) of the storefronts to query. The lookup is repeated for each storefront, results are tagged with their `storefront`, and `availability` reports where each requested item was found. Items are only treated as missing (see `on_missing`) when no storefront returns them. Cannot be used with `country`, `term`, or `app_store_urls`, whose URLs already carry their storefront.",
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(
+						path.MatchRoot("term"),
+						path.MatchRoot("app_store_urls"),
+					),
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(common.CountryCodeRegex, "must be a valid ISO 3166-1 alpha-2 country code"),
+					),
+				},
+			},
 			"media": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Media type, defaults to 'all'. Supported values: 'movie', 'podcast', 'music', 'musicVideo', 'audiobook', 'shortFilm', 'tvShow', 'software', 'ebook', 'all'. See the iTunes Search API documentation for more details.",
@@ -323,6 +339,14 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 					stringvalidator.AlsoRequires(path.MatchRoot("term")),
 				},
 			},
+			"max_results": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Enables automatic pagination for term-based searches. The provider follows result offsets until Apple runs out of results or this many unique results (by track ID) have been collected. When set, `limit` is used as the page size (default 200).",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("term")),
+					int64validator.AtLeast(1),
+				},
+			},
 			"limit": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "Maximum number of results to return. For lookups, this overrides the provider-managed defaults when you need to limit nested collections (for example, top 5 albums per artist). Valid range is 1-200.",
@@ -331,6 +355,73 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 					int64validator.AtMost(200),
 				},
 			},
+			"download_artwork": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to download each result's artwork and expose it as `artwork_base64`. Defaults to true. Disable this for large result sets to avoid extra requests and state growth.",
+			},
+			"artwork_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Edge length in pixels of the square artwork referenced by `artwork_url` and downloaded into `artwork_base64` (for example 60, 100, 512, or 1024). Defaults to 512.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtMost(4096),
+				},
+			},
+			"artwork_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Image format of the artwork referenced by `artwork_url` and downloaded into `artwork_base64`. Allowed values: png, jpg, webp. Defaults to png.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.ArtworkFormats...),
+				},
+			},
+			"artwork_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of artwork images downloaded in parallel when `download_artwork` is enabled. Defaults to 8.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtMost(64),
+				},
+			},
+			"on_missing": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How lookups handle IDs, App Store URLs, or other selector values that return no results. `error` (the default) fails the read; `warn` returns the results that were found and emits a warning; `ignore` returns the results that were found silently. Missing values are always listed in `missing`. UPCs, ISBNs, and AMG album and video IDs are not always returned in results, so missing ones are found by counting results and bisecting the values with up to 8 extra lookups per request; a value missing from a group that cannot be narrowed down within that limit, or hidden by another value in its group that returns several albums, is not reported. These extra lookups count against `rate_limit_requests`. Missing values are not reported, and no extra lookups are sent, when `limit` could cut rows from the response: when it is below the number of values, or when `entity` is set with `limit` (`ids` and `app_store_urls` lookups apply a limit whenever `entity` is set).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.OnMissingModes...),
+					stringvalidator.ConflictsWith(path.MatchRoot("term")),
+				},
+			},
+			"missing": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "Selector values from the lookup that returned no results, for example delisted apps. Always empty for searches.",
+				ElementType:         types.StringType,
+			},
+			"availability": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Per-item storefront availability for lookups, in the order the items were requested. Always empty for searches.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"item": schema.StringAttribute{
+							MarkdownDescription: "Requested selector value, such as an ID, bundle ID, or App Store URL.",
+							Computed:            true,
+						},
+						"countries": schema.MapAttribute{
+							MarkdownDescription: "Map of storefront code to whether the item was found there.",
+							Computed:            true,
+							ElementType:         types.BoolType,
+						},
+						"available_in": schema.ListAttribute{
+							MarkdownDescription: "Storefronts that returned the item.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"missing_in": schema.ListAttribute{
+							MarkdownDescription: "Storefronts that did not return the item.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
 			"results": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "List of content search results.",
@@ -397,11 +488,11 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							Computed:            true,
 						},
 						"artwork_url": schema.StringAttribute{
-							MarkdownDescription: "Artwork URL.",
+							MarkdownDescription: "Artwork URL, rewritten to the configured `artwork_size` and `artwork_format`.",
 							Computed:            true,
 						},
 						"artwork_base64": schema.StringAttribute{
-							MarkdownDescription: "Base64-encoded artwork image.",
+							MarkdownDescription: "Base64-encoded artwork image. Empty when `download_artwork` is false.",
 							Computed:            true,
 						},
 						"track_view_url": schema.StringAttribute{
@@ -431,6 +522,509 @@ func (d *ContentDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 							MarkdownDescription: "Number of ratings.",
 							Computed:            true,
 						},
+						"wrapper_type": schema.StringAttribute{
+							MarkdownDescription: "Wrapper type of the result (e.g., track, collection, artist, software, audiobook).",
+							Computed:            true,
+						},
+						"artist_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes artist ID.",
+							Computed:            true,
+						},
+						"artist_ids": schema.ListAttribute{
+							MarkdownDescription: "iTunes artist IDs, for content with several authors (e.g., ebooks, podcast episodes).",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
+						"amg_artist_id": schema.Int64Attribute{
+							MarkdownDescription: "AMG artist ID.",
+							Computed:            true,
+						},
+						"artist_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the artist, author, or developer.",
+							Computed:            true,
+						},
+						"artist_type": schema.StringAttribute{
+							MarkdownDescription: "Type of artist (e.g., Artist, Author).",
+							Computed:            true,
+						},
+						"artist_link_url": schema.StringAttribute{
+							MarkdownDescription: "URL to the artist page.",
+							Computed:            true,
+						},
+						"collection_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes collection ID.",
+							Computed:            true,
+						},
+						"collection_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the collection (e.g., album, podcast, season).",
+							Computed:            true,
+						},
+						"collection_censored_name": schema.StringAttribute{
+							MarkdownDescription: "Censored name of the collection.",
+							Computed:            true,
+						},
+						"collection_artist_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes ID of the collection artist, when different from the track artist.",
+							Computed:            true,
+						},
+						"collection_artist_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the collection artist, when different from the track artist.",
+							Computed:            true,
+						},
+						"collection_view_url": schema.StringAttribute{
+							MarkdownDescription: "URL to collection view.",
+							Computed:            true,
+						},
+						"collection_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the collection (e.g., Album, Compilation).",
+							Computed:            true,
+						},
+						"track_censored_name": schema.StringAttribute{
+							MarkdownDescription: "Censored name of the track.",
+							Computed:            true,
+						},
+						"preview_url": schema.StringAttribute{
+							MarkdownDescription: "URL to a preview of the content.",
+							Computed:            true,
+						},
+						"artwork_url_30": schema.StringAttribute{
+							MarkdownDescription: "URL to 30x30 artwork.",
+							Computed:            true,
+						},
+						"artwork_url_60": schema.StringAttribute{
+							MarkdownDescription: "URL to 60x60 artwork.",
+							Computed:            true,
+						},
+						"artwork_url_100": schema.StringAttribute{
+							MarkdownDescription: "URL to 100x100 artwork.",
+							Computed:            true,
+						},
+						"artwork_url_600": schema.StringAttribute{
+							MarkdownDescription: "URL to 600x600 artwork (podcasts).",
+							Computed:            true,
+						},
+						"collection_price": schema.Float64Attribute{
+							MarkdownDescription: "Price of the collection.",
+							Computed:            true,
+						},
+						"collection_hd_price": schema.Float64Attribute{
+							MarkdownDescription: "HD price of the collection.",
+							Computed:            true,
+						},
+						"track_price": schema.Float64Attribute{
+							MarkdownDescription: "Price of the track.",
+							Computed:            true,
+						},
+						"track_hd_price": schema.Float64Attribute{
+							MarkdownDescription: "HD price of the track.",
+							Computed:            true,
+						},
+						"track_rental_price": schema.Float64Attribute{
+							MarkdownDescription: "Rental price of the track.",
+							Computed:            true,
+						},
+						"track_hd_rental_price": schema.Float64Attribute{
+							MarkdownDescription: "HD rental price of the track.",
+							Computed:            true,
+						},
+						"country": schema.StringAttribute{
+							MarkdownDescription: "Storefront country (ISO 3166-1 alpha-3).",
+							Computed:            true,
+						},
+						"collection_explicitness": schema.StringAttribute{
+							MarkdownDescription: "Explicitness of the collection (explicit, cleaned, notExplicit).",
+							Computed:            true,
+						},
+						"track_explicitness": schema.StringAttribute{
+							MarkdownDescription: "Explicitness of the track (explicit, cleaned, notExplicit).",
+							Computed:            true,
+						},
+						"content_advisory_rating": schema.StringAttribute{
+							MarkdownDescription: "Content advisory rating (e.g., Explicit, 12+, PG-13).",
+							Computed:            true,
+						},
+						"track_content_rating": schema.StringAttribute{
+							MarkdownDescription: "Content rating of the track.",
+							Computed:            true,
+						},
+						"advisories": schema.ListAttribute{
+							MarkdownDescription: "List of content advisories.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"disc_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of discs in the collection.",
+							Computed:            true,
+						},
+						"disc_number": schema.Int64Attribute{
+							MarkdownDescription: "Disc number of the track.",
+							Computed:            true,
+						},
+						"track_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of tracks in the collection.",
+							Computed:            true,
+						},
+						"track_number": schema.Int64Attribute{
+							MarkdownDescription: "Track number within the disc.",
+							Computed:            true,
+						},
+						"track_time_millis": schema.Int64Attribute{
+							MarkdownDescription: "Duration of the track in milliseconds.",
+							Computed:            true,
+						},
+						"primary_genre_id": schema.Int64Attribute{
+							MarkdownDescription: "Primary genre ID.",
+							Computed:            true,
+						},
+						"genre_ids": schema.ListAttribute{
+							MarkdownDescription: "List of genre IDs.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"short_description": schema.StringAttribute{
+							MarkdownDescription: "Short description of the content.",
+							Computed:            true,
+						},
+						"long_description": schema.StringAttribute{
+							MarkdownDescription: "Long description of the content.",
+							Computed:            true,
+						},
+						"copyright": schema.StringAttribute{
+							MarkdownDescription: "Copyright notice.",
+							Computed:            true,
+						},
+						"is_streamable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the track is streamable.",
+							Computed:            true,
+						},
+						"has_itunes_extras": schema.BoolAttribute{
+							MarkdownDescription: "Whether the content includes iTunes Extras.",
+							Computed:            true,
+						},
+						"feed_url": schema.StringAttribute{
+							MarkdownDescription: "RSS feed URL (podcasts).",
+							Computed:            true,
+						},
+						"episode_url": schema.StringAttribute{
+							MarkdownDescription: "Media URL of the episode (podcast episodes).",
+							Computed:            true,
+						},
+						"episode_guid": schema.StringAttribute{
+							MarkdownDescription: "GUID of the episode (podcast episodes).",
+							Computed:            true,
+						},
+						"episode_file_extension": schema.StringAttribute{
+							MarkdownDescription: "File extension of the episode media (podcast episodes).",
+							Computed:            true,
+						},
+						"episode_content_type": schema.StringAttribute{
+							MarkdownDescription: "Content type of the episode (podcast episodes).",
+							Computed:            true,
+						},
+						"seller_url": schema.StringAttribute{
+							MarkdownDescription: "URL to the seller's website.",
+							Computed:            true,
+						},
+						"release_notes": schema.StringAttribute{
+							MarkdownDescription: "Release notes for the current version.",
+							Computed:            true,
+						},
+						"current_version_release_date": schema.StringAttribute{
+							MarkdownDescription: "Release date of the current version.",
+							Computed:            true,
+						},
+						"screenshot_urls": schema.ListAttribute{
+							MarkdownDescription: "List of iPhone screenshot URLs.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"ipad_screenshot_urls": schema.ListAttribute{
+							MarkdownDescription: "List of iPad screenshot URLs.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"appletv_screenshot_urls": schema.ListAttribute{
+							MarkdownDescription: "List of Apple TV screenshot URLs.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"features": schema.ListAttribute{
+							MarkdownDescription: "List of app features (e.g., iosUniversal).",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"is_game_center_enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the app supports Game Center.",
+							Computed:            true,
+						},
+						"average_rating_current_version": schema.Float64Attribute{
+							MarkdownDescription: "Average user rating for the current version.",
+							Computed:            true,
+						},
+						"rating_count_current_version": schema.Int64Attribute{
+							MarkdownDescription: "Number of ratings for the current version.",
+							Computed:            true,
+						},
+						"storefront": schema.StringAttribute{
+							MarkdownDescription: "Lowercase ISO 3166-1 alpha-2 code of the storefront that returned this result.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"artists": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Artist rows (wrapperType `artist`) from the results.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"artist_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes artist ID.",
+							Computed:            true,
+						},
+						"amg_artist_id": schema.Int64Attribute{
+							MarkdownDescription: "AMG artist ID.",
+							Computed:            true,
+						},
+						"artist_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the artist.",
+							Computed:            true,
+						},
+						"artist_type": schema.StringAttribute{
+							MarkdownDescription: "Type of artist (e.g., Artist, Author).",
+							Computed:            true,
+						},
+						"artist_link_url": schema.StringAttribute{
+							MarkdownDescription: "URL to the artist page.",
+							Computed:            true,
+						},
+						"primary_genre": schema.StringAttribute{
+							MarkdownDescription: "Primary genre.",
+							Computed:            true,
+						},
+						"primary_genre_id": schema.Int64Attribute{
+							MarkdownDescription: "Primary genre ID.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"collections": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collection rows (wrapperType `collection` or `audiobook`) from the results. `collection_type` is null for audiobooks.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"wrapper_type": schema.StringAttribute{
+							MarkdownDescription: "Wrapper type of the row (collection or audiobook).",
+							Computed:            true,
+						},
+						"collection_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes collection ID.",
+							Computed:            true,
+						},
+						"collection_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the collection.",
+							Computed:            true,
+						},
+						"collection_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the collection (e.g., Album, Compilation).",
+							Computed:            true,
+						},
+						"collection_view_url": schema.StringAttribute{
+							MarkdownDescription: "URL to collection view.",
+							Computed:            true,
+						},
+						"artist_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes artist ID.",
+							Computed:            true,
+						},
+						"artist_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the artist or author.",
+							Computed:            true,
+						},
+						"artwork_url": schema.StringAttribute{
+							MarkdownDescription: "URL to 100x100 artwork.",
+							Computed:            true,
+						},
+						"collection_price": schema.Float64Attribute{
+							MarkdownDescription: "Price of the collection.",
+							Computed:            true,
+						},
+						"currency": schema.StringAttribute{
+							MarkdownDescription: "Currency code.",
+							Computed:            true,
+						},
+						"collection_explicitness": schema.StringAttribute{
+							MarkdownDescription: "Explicitness of the collection.",
+							Computed:            true,
+						},
+						"track_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of tracks in the collection.",
+							Computed:            true,
+						},
+						"release_date": schema.StringAttribute{
+							MarkdownDescription: "Release date.",
+							Computed:            true,
+						},
+						"primary_genre": schema.StringAttribute{
+							MarkdownDescription: "Primary genre.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"tracks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Track rows (wrapperType `track` or `podcastEpisode`, plus ebooks) from the results. Podcast episodes have null artist, price, explicitness, preview, and track numbering attributes, and other tracks have a null `episode_url`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"wrapper_type": schema.StringAttribute{
+							MarkdownDescription: "Wrapper type of the row (track or podcastEpisode).",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Kind of content (e.g., song, feature-movie, podcast-episode).",
+							Computed:            true,
+						},
+						"track_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes track ID.",
+							Computed:            true,
+						},
+						"track_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the track.",
+							Computed:            true,
+						},
+						"track_view_url": schema.StringAttribute{
+							MarkdownDescription: "URL to track view.",
+							Computed:            true,
+						},
+						"artist_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes artist ID.",
+							Computed:            true,
+						},
+						"artist_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the artist.",
+							Computed:            true,
+						},
+						"collection_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes collection ID.",
+							Computed:            true,
+						},
+						"collection_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the collection.",
+							Computed:            true,
+						},
+						"track_number": schema.Int64Attribute{
+							MarkdownDescription: "Track number within the disc.",
+							Computed:            true,
+						},
+						"track_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of tracks in the collection.",
+							Computed:            true,
+						},
+						"disc_number": schema.Int64Attribute{
+							MarkdownDescription: "Disc number of the track.",
+							Computed:            true,
+						},
+						"track_time_millis": schema.Int64Attribute{
+							MarkdownDescription: "Duration of the track in milliseconds.",
+							Computed:            true,
+						},
+						"preview_url": schema.StringAttribute{
+							MarkdownDescription: "URL to a preview of the track.",
+							Computed:            true,
+						},
+						"episode_url": schema.StringAttribute{
+							MarkdownDescription: "Media URL of the episode (podcast episodes).",
+							Computed:            true,
+						},
+						"track_price": schema.Float64Attribute{
+							MarkdownDescription: "Price of the track.",
+							Computed:            true,
+						},
+						"currency": schema.StringAttribute{
+							MarkdownDescription: "Currency code.",
+							Computed:            true,
+						},
+						"track_explicitness": schema.StringAttribute{
+							MarkdownDescription: "Explicitness of the track.",
+							Computed:            true,
+						},
+						"release_date": schema.StringAttribute{
+							MarkdownDescription: "Release date.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"software": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Software rows (wrapperType `software`) from the results.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"track_id": schema.Int64Attribute{
+							MarkdownDescription: "iTunes track ID.",
+							Computed:            true,
+						},
+						"track_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the app.",
+							Computed:            true,
+						},
+						"track_view_url": schema.StringAttribute{
+							MarkdownDescription: "URL to the App Store page.",
+							Computed:            true,
+						},
+						"bundle_id": schema.StringAttribute{
+							MarkdownDescription: "Bundle ID of the app.",
+							Computed:            true,
+						},
+						"seller_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the seller.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Current version.",
+							Computed:            true,
+						},
+						"minimum_os_version": schema.StringAttribute{
+							MarkdownDescription: "Minimum OS version required.",
+							Computed:            true,
+						},
+						"file_size_bytes": schema.StringAttribute{
+							MarkdownDescription: "File size in bytes.",
+							Computed:            true,
+						},
+						"price": schema.Float64Attribute{
+							MarkdownDescription: "Price.",
+							Computed:            true,
+						},
+						"formatted_price": schema.StringAttribute{
+							MarkdownDescription: "Formatted price string.",
+							Computed:            true,
+						},
+						"currency": schema.StringAttribute{
+							MarkdownDescription: "Currency code.",
+							Computed:            true,
+						},
+						"release_date": schema.StringAttribute{
+							MarkdownDescription: "Original release date.",
+							Computed:            true,
+						},
+						"current_version_release_date": schema.StringAttribute{
+							MarkdownDescription: "Release date of the current version.",
+							Computed:            true,
+						},
+						"primary_genre": schema.StringAttribute{
+							MarkdownDescription: "Primary genre.",
+							Computed:            true,
+						},
+						"average_rating": schema.Float64Attribute{
+							MarkdownDescription: "Average user rating.",
+							Computed:            true,
+						},
+						"rating_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of ratings.",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -479,6 +1073,8 @@ func (d *ContentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	defer cancel()
 
 	var results []client.ContentResult
+	data.Missing = []types.String{}
+	data.Availability = []AvailabilityModel{}
 
 	if !data.Term.IsNull() {
 		searchResults, diags := executeSearch(readCtx, data, d.client)
@@ -496,10 +1092,21 @@ func (d *ContentDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		results = lookupResults
 	}
 
-	data.Results = mapResultsToModel(readCtx, results)
+	data.Results = mapResultsToModel(readCtx, d.client, results, artworkOptionsFromModel(data))
+
+	typed := mapTypedResultsToModel(results)
+	data.Artists = typed.Artists
+	data.Collections = typed.Collections
+	data.Tracks = typed.Tracks
+	data.Software = typed.Software
 
 	tflog.Debug(ctx, "Content data source read", map[string]any{
-		"result_count": len(data.Results),
+		"result_count":     len(data.Results),
+		"artist_count":     len(data.Artists),
+		"collection_count": len(data.Collections),
+		"track_count":      len(data.Tracks),
+		"software_count":   len(data.Software),
+		"missing_count":    len(data.Missing),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
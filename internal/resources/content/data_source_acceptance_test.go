@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/provider"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/acctest"
 )
 
 // providerFactories returns a map of provider factories for acceptance tests.
@@ -22,6 +23,7 @@ var providerFactories = map[string]func() (tfprotov6.ProviderServer, error){
 
 func TestAccContentDataSource_BundleID(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -44,6 +46,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_MultipleBundleIDs(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -62,6 +65,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_TermSearch(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -84,6 +88,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_TermSearchWithEntity(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -106,6 +111,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_AppStoreURL(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -126,6 +132,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_WithCountry(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -146,6 +153,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_ResultFieldsPopulated(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -176,6 +184,7 @@ data "itunessearchapi_content" "test" {
 
 func TestAccContentDataSource_MusicSearch(t *testing.T) {
 	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
 		ProtoV6ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{
@@ -42,6 +42,10 @@ func TestContentDataSource_Schema(t *testing.T) {
 		"upcs", "isbns", "bundle_ids", "country", "media",
 		"entity", "sort", "attribute", "lang", "version",
 		"explicit", "offset", "callback", "limit", "results",
+		"artists", "collections", "tracks", "software", "max_results",
+		"download_artwork", "artwork_size", "artwork_format",
+		"artwork_concurrency", "on_missing", "missing",
+		"countries", "availability",
 	}
 
 	for _, attr := range requiredAttrs {
@@ -6,13 +6,10 @@ package content
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"regexp"
-	"strconv"
-	"strings"
-	"time"
+	"slices"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,21 +17,9 @@ import (
 
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
 	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/common"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/diagnostics"
 )
 
-// appStoreURLRegex matches App Store URLs and captures country code and track ID.
-var appStoreURLRegex = regexp.MustCompile(`^https://apps\.apple\.com/([a-z]{2})/.*?/id(\d+)`)
-
-// parseAppStoreURL extracts the track ID and country code from an App Store URL.
-func parseAppStoreURL(urlStr string) (trackID int64, countryCode string) {
-	matches := appStoreURLRegex.FindStringSubmatch(urlStr)
-
-	countryCode = matches[1]
-	trackID, _ = strconv.ParseInt(matches[2], 10, 64)
-
-	return trackID, countryCode
-}
-
 // lookupLimitForBatch returns the effective limit for a lookup request batch.
 func lookupLimitForBatch(limit types.Int64, batchSize int, autoAlign bool) int64 {
 	if !limit.IsNull() && !limit.IsUnknown() {
@@ -56,75 +41,269 @@ func buildLookupRequest(data ContentDataSourceModel) client.LookupRequest {
 }
 
 // downloadAndEncodeImage downloads an image from a URL and returns it as a base64-encoded string.
-func downloadAndEncodeImage(ctx context.Context, httpClient *http.Client, imageURL string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+func downloadAndEncodeImage(ctx context.Context, c *client.Client, imageURL string) (string, error) {
+	imageData, err := c.FetchArtwork(ctx, imageURL)
 	if err != nil {
-		return "", fmt.Errorf("error creating request: %w", err)
+		return "", err
 	}
 
-	req.Header.Set("User-Agent", "Terraform-Provider-iTunesSearchAPI")
-	req.Header.Set("Accept", "image/*")
+	return base64.StdEncoding.EncodeToString(imageData), nil
+}
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("error downloading image: %w", err)
+// fetchArtwork downloads and encodes the artwork for each URL using at most
+// concurrency workers. Empty URLs are skipped. The returned slices are indexed
+// like urls, so callers can match each image or error back to its result.
+func fetchArtwork(ctx context.Context, c *client.Client, urls []string, concurrency int) ([]string, []error) {
+	encoded := make([]string, len(urls))
+	errs := make([]error, len(urls))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(concurrency, len(urls))) {
+		wg.Go(func() {
+			for i := range jobs {
+				encoded[i], errs[i] = downloadAndEncodeImage(ctx, c, urls[i])
+			}
+		})
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("image download failed with status code: %d", resp.StatusCode)
+	for i, u := range urls {
+		if u != "" {
+			jobs <- i
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	imageData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	return encoded, errs
+}
+
+// lookupBatchResult holds the outcome of a single lookup batch.
+type lookupBatchResult struct {
+	result *client.ContentResponse
+	err    error
+}
+
+// runLookupBatches issues the lookup requests through a bounded worker pool and
+// returns their outcomes in input order. Every request still draws from the
+// client's shared rate limiter. Once a batch fails with an error other than
+// *client.NotFoundError no further batches are started, and those skipped
+// report that error, so callers that stop at the first failure in input order
+// see the same error the serial loop would have returned.
+func runLookupBatches(ctx context.Context, c *client.Client, reqs []client.LookupRequest, concurrency int) []lookupBatchResult {
+	outcomes := make([]lookupBatchResult, len(reqs))
+	dispatchCtx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(concurrency, len(reqs))) {
+		wg.Go(func() {
+			for i := range jobs {
+				if dispatchCtx.Err() != nil {
+					outcomes[i] = lookupBatchResult{err: context.Cause(dispatchCtx)}
+					continue
+				}
+				result, err := c.Lookup(ctx, reqs[i])
+				outcomes[i] = lookupBatchResult{result: result, err: err}
+				var notFoundErr *client.NotFoundError
+				if err != nil && !errors.As(err, &notFoundErr) {
+					stop(err)
+				}
+			}
+		})
 	}
 
-	return base64.StdEncoding.EncodeToString(imageData), nil
+	dispatched := 0
+dispatch:
+	for i := range reqs {
+		select {
+		case jobs <- i:
+			dispatched++
+		case <-dispatchCtx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := dispatched; i < len(outcomes); i++ {
+		outcomes[i] = lookupBatchResult{err: context.Cause(dispatchCtx)}
+	}
+
+	return outcomes
 }
 
-// executeLookup dispatches the appropriate lookup request based on which selector
-// is set in the data model, handling batching and error aggregation.
+// executeLookup performs the lookup for the selector set in the data model,
+// repeating it for each storefront when countries is set, and records the
+// per-item availability.
 func executeLookup(ctx context.Context, data *ContentDataSourceModel, c *client.Client) ([]client.ContentResult, diag.Diagnostics) {
+	if !data.AppStoreURLs.IsNull() {
+		return executeLookupAppStoreURLs(ctx, data, c)
+	}
+	if !data.Countries.IsNull() {
+		return executeLookupCountries(ctx, data, c)
+	}
+
+	results, diags := executeLookupSelector(ctx, data, c)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	values, valueDiags := selectorValues(ctx, *data)
+	diags.Append(valueDiags...)
+	country := data.Country.ValueString()
+	if country == "" {
+		country = c.DefaultCountry()
+	}
+	data.Availability = buildAvailability(values, []string{country}, map[string][]types.String{country: data.Missing})
+
+	return results, diags
+}
+
+// executeLookupCountries repeats the lookup in each requested storefront and
+// merges the results in storefront order. Items missing from some storefronts
+// are only reported in availability; items missing from all of them are
+// handled according to on_missing.
+func executeLookupCountries(ctx context.Context, data *ContentDataSourceModel, c *client.Client) ([]client.ContentResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var countries []string
+	diags.Append(data.Countries.ElementsAs(ctx, &countries, false)...)
+	values, valueDiags := selectorValues(ctx, *data)
+	diags.Append(valueDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var results []client.ContentResult
+	missingByCountry := make(map[string][]types.String, len(countries))
+	for _, country := range countries {
+		storefront := *data
+		storefront.Country = types.StringValue(country)
+		storefront.Countries = types.ListNull(types.StringType)
+		storefront.OnMissing = types.StringValue(common.OnMissingIgnore)
+
+		countryResults, countryDiags := executeLookupSelector(ctx, &storefront, c)
+		diags.Append(countryDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		results = append(results, countryResults...)
+		missingByCountry[country] = storefront.Missing
+	}
+
+	data.Availability = buildAvailability(values, countries, missingByCountry)
+
+	var missingEverywhere []string
+	for _, item := range data.Availability {
+		if len(item.AvailableIn) == 0 {
+			missingEverywhere = append(missingEverywhere, item.Item.ValueString())
+		}
+	}
+	if handleMissing(data, &diags, "Resources Not Found", fmt.Sprintf("The following items were not found in any requested storefront: %v", missingEverywhere), missingEverywhere) {
+		return nil, diags
+	}
+
+	return results, diags
+}
+
+// selectorValues returns the values of the lookup selector set in the data
+// model, formatted as they appear in missing.
+func selectorValues(ctx context.Context, data ContentDataSourceModel) ([]string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	switch {
-	case !data.AppStoreURLs.IsNull():
-		return executeLookupAppStoreURLs(ctx, data, c)
+	for _, field := range []types.List{data.IDs, data.AMGArtistIDs, data.AMGAlbumIDs, data.AMGVideoIDs} {
+		if !field.IsNull() {
+			var ids []int64
+			diags.Append(field.ElementsAs(ctx, &ids, false)...)
+			return formatValues(ids), diags
+		}
+	}
+	for _, field := range []types.List{data.UPCs, data.ISBNs, data.BundleIDs, data.AppStoreURLs} {
+		if !field.IsNull() {
+			var values []string
+			diags.Append(field.ElementsAs(ctx, &values, false)...)
+			return values, diags
+		}
+	}
 
+	return nil, diags
+}
+
+// buildAvailability builds the availability matrix for the requested items
+// from the values each storefront reported missing.
+func buildAvailability(values, countries []string, missingByCountry map[string][]types.String) []AvailabilityModel {
+	availability := make([]AvailabilityModel, 0, len(values))
+	for _, value := range values {
+		item := AvailabilityModel{
+			Item:        types.StringValue(value),
+			Countries:   make(map[string]types.Bool, len(countries)),
+			AvailableIn: []types.String{},
+			MissingIn:   []types.String{},
+		}
+		for _, country := range countries {
+			found := !slices.Contains(missingByCountry[country], types.StringValue(value))
+			item.Countries[country] = types.BoolValue(found)
+			if found {
+				item.AvailableIn = append(item.AvailableIn, types.StringValue(country))
+			} else {
+				item.MissingIn = append(item.MissingIn, types.StringValue(country))
+			}
+		}
+		availability = append(availability, item)
+	}
+	return availability
+}
+
+// executeLookupSelector dispatches the appropriate lookup request based on which selector
+// is set in the data model, handling batching and error aggregation.
+func executeLookupSelector(ctx context.Context, data *ContentDataSourceModel, c *client.Client) ([]client.ContentResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
 	case !data.IDs.IsNull():
 		return executeLookupIDs(ctx, data, c)
 
 	case !data.AMGArtistIDs.IsNull():
 		return executeLookupInt64Field(ctx, data.AMGArtistIDs, data, c, func(req *client.LookupRequest, batch []int64) {
 			req.AMGArtistIDs = batch
-		}, false)
+		}, false, "AMG artist IDs", func(notFoundErr *client.NotFoundError) []int64 {
+			return notFoundErr.MissingAMGIDs
+		})
 
 	case !data.AMGAlbumIDs.IsNull():
 		return executeLookupInt64Field(ctx, data.AMGAlbumIDs, data, c, func(req *client.LookupRequest, batch []int64) {
 			req.AMGAlbumIDs = batch
-		}, false)
+		}, false, "AMG album IDs", func(notFoundErr *client.NotFoundError) []int64 {
+			return notFoundErr.MissingAMGIDs
+		})
 
 	case !data.AMGVideoIDs.IsNull():
 		return executeLookupInt64Field(ctx, data.AMGVideoIDs, data, c, func(req *client.LookupRequest, batch []int64) {
 			req.AMGVideoIDs = batch
-		}, false)
+		}, false, "AMG video IDs", func(notFoundErr *client.NotFoundError) []int64 {
+			return notFoundErr.MissingAMGIDs
+		})
 
 	case !data.UPCs.IsNull():
 		return executeLookupStringField(ctx, data.UPCs, data, c, func(req *client.LookupRequest, batch []string) {
 			req.UPCs = batch
+		}, "UPCs", func(notFoundErr *client.NotFoundError) []string {
+			return notFoundErr.MissingUPCs
 		})
 
 	case !data.ISBNs.IsNull():
 		return executeLookupStringField(ctx, data.ISBNs, data, c, func(req *client.LookupRequest, batch []string) {
 			req.ISBNs = batch
+		}, "ISBNs", func(notFoundErr *client.NotFoundError) []string {
+			return notFoundErr.MissingISBNs
 		})
 
 	case !data.BundleIDs.IsNull():
 		return executeLookupStringField(ctx, data.BundleIDs, data, c, func(req *client.LookupRequest, batch []string) {
 			req.BundleIDs = batch
+		}, "bundle IDs", func(notFoundErr *client.NotFoundError) []string {
+			return notFoundErr.MissingBundleIDs
 		})
 	}
 
@@ -132,7 +311,17 @@ func executeLookup(ctx context.Context, data *ContentDataSourceModel, c *client.
 	return nil, diags
 }
 
-// executeLookupAppStoreURLs handles lookup requests using App Store URLs.
+// appleURLGroup identifies Apple media URLs that are looked up together: those
+// naming the same storefront and inferred to have the same media and entity.
+type appleURLGroup struct {
+	country, media, entity string
+}
+
+// executeLookupAppStoreURLs handles lookup requests using App Store URLs. URLs
+// are grouped by the storefront they name and, unless entity is set, by the
+// media and entity inferred from them, and each group is looked up in turn,
+// so URLs from different countries and for different kinds of content can be
+// mixed.
 func executeLookupAppStoreURLs(ctx context.Context, data *ContentDataSourceModel, c *client.Client) ([]client.ContentResult, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var urls []string
@@ -141,55 +330,89 @@ func executeLookupAppStoreURLs(ctx context.Context, data *ContentDataSourceModel
 		return nil, diags
 	}
 
-	var trackIDs []int64
-	countryCode := ""
+	var countries []string
+	var groups []appleURLGroup
+	urlGroups := make([]appleURLGroup, len(urls))
+	parsedURLs := make([]common.AppleURL, len(urls))
+	trackIDsByGroup := make(map[appleURLGroup][]int64)
 
-	for _, urlStr := range urls {
-		trackID, country := parseAppStoreURL(urlStr)
-		if countryCode == "" {
-			countryCode = country
-		} else if countryCode != country {
-			diags.AddError("Inconsistent Countries", "All App Store URLs must be from the same country store.")
+	for i, urlStr := range urls {
+		parsed, err := common.ParseAppleURL(urlStr, c.DefaultCountry())
+		if err != nil {
+			diags.AddError("Invalid App Store URL", err.Error())
 			return nil, diags
 		}
-		trackIDs = append(trackIDs, trackID)
+		if !slices.Contains(countries, parsed.Country) {
+			countries = append(countries, parsed.Country)
+		}
+		group := appleURLGroup{country: parsed.Country}
+		if data.Entity.IsNull() {
+			group.media, group.entity = parsed.Media, parsed.Entity
+		}
+		if _, seen := trackIDsByGroup[group]; !seen {
+			groups = append(groups, group)
+		}
+		parsedURLs[i] = parsed
+		urlGroups[i] = group
+		trackIDsByGroup[group] = append(trackIDsByGroup[group], parsed.ID)
 	}
 
-	data.Country = types.StringValue(countryCode)
-	baseRequest := buildLookupRequest(*data)
+	if len(countries) == 1 {
+		data.Country = types.StringValue(countries[0])
+	}
 
 	var results []client.ContentResult
 	var allMissingURLs []string
 
-	batches := common.ChunkInt64(trackIDs, common.MaxLookupBatchSize)
-	for _, batch := range batches {
-		req := baseRequest
-		req.IDs = batch
-		req.Limit = lookupLimitForBatch(data.Limit, len(batch), true)
+	for _, group := range groups {
+		storefront := *data
+		storefront.Country = types.StringValue(group.country)
+		baseRequest := buildLookupRequest(storefront)
+		if group.entity != "" {
+			baseRequest.Media, baseRequest.Entity = group.media, group.entity
+		}
 
-		result, err := c.Lookup(ctx, req)
-		if err != nil {
-			if notFoundErr, ok := err.(*client.NotFoundError); ok {
-				for _, id := range notFoundErr.MissingIDs {
-					for _, url := range urls {
-						if strings.Contains(url, fmt.Sprintf("id%d", id)) {
-							allMissingURLs = append(allMissingURLs, url)
+		// The limit is not aligned to the batch for an inferred entity, which
+		// the client treats as possibly truncating and so would not report
+		// missing URLs.
+		var reqs []client.LookupRequest
+		for _, batch := range common.ChunkInt64(trackIDsByGroup[group], common.MaxLookupBatchSize) {
+			req := baseRequest
+			req.IDs = batch
+			req.Limit = lookupLimitForBatch(data.Limit, len(batch), group.entity == "")
+			reqs = append(reqs, req)
+		}
+
+		for _, batch := range runLookupBatches(ctx, c, reqs, common.LookupConcurrency) {
+			result, err := batch.result, batch.err
+			if err != nil {
+				var notFoundErr *client.NotFoundError
+				if errors.As(err, &notFoundErr) {
+					for i, parsed := range parsedURLs {
+						if urlGroups[i] == group && slices.Contains(notFoundErr.MissingIDs, parsed.ID) {
+							allMissingURLs = append(allMissingURLs, urls[i])
 						}
 					}
+					if result != nil {
+						results = append(results, result.Results...)
+					}
+					continue
 				}
-				if result != nil {
-					results = append(results, result.Results...)
-				}
-				continue
+				diags.AddError(diagnostics.APIError(err))
+				return nil, diags
 			}
-			diags.AddError("API Request Failed", err.Error())
-			return nil, diags
+			results = append(results, result.Results...)
 		}
-		results = append(results, result.Results...)
 	}
 
-	if len(allMissingURLs) > 0 {
-		diags.AddError("Some URLs not found", fmt.Sprintf("The following URLs were not found: %v", allMissingURLs))
+	data.Availability = make([]AvailabilityModel, 0, len(urls))
+	for i, urlStr := range urls {
+		country := parsedURLs[i].Country
+		item := buildAvailability([]string{urlStr}, []string{country}, map[string][]types.String{country: stringValues(allMissingURLs)})
+		data.Availability = append(data.Availability, item...)
+	}
+
+	if handleMissing(data, &diags, "Some URLs not found", fmt.Sprintf("The following URLs were not found: %v", allMissingURLs), allMissingURLs) {
 		return nil, diags
 	}
 
@@ -209,29 +432,32 @@ func executeLookupIDs(ctx context.Context, data *ContentDataSourceModel, c *clie
 	var allMissingIDs []int64
 	baseRequest := buildLookupRequest(*data)
 
-	batches := common.ChunkInt64(ids, common.MaxLookupBatchSize)
-	for _, batch := range batches {
+	var reqs []client.LookupRequest
+	for _, batch := range common.ChunkInt64(ids, common.MaxLookupBatchSize) {
 		req := baseRequest
 		req.IDs = batch
 		req.Limit = lookupLimitForBatch(data.Limit, len(batch), true)
+		reqs = append(reqs, req)
+	}
 
-		result, err := c.Lookup(ctx, req)
+	for _, batch := range runLookupBatches(ctx, c, reqs, common.LookupConcurrency) {
+		result, err := batch.result, batch.err
 		if err != nil {
-			if notFoundErr, ok := err.(*client.NotFoundError); ok {
+			var notFoundErr *client.NotFoundError
+			if errors.As(err, &notFoundErr) {
 				allMissingIDs = append(allMissingIDs, notFoundErr.MissingIDs...)
 				if result != nil {
 					results = append(results, result.Results...)
 				}
 				continue
 			}
-			diags.AddError("API Request Failed", err.Error())
+			diags.AddError(diagnostics.APIError(err))
 			return nil, diags
 		}
 		results = append(results, result.Results...)
 	}
 
-	if len(allMissingIDs) > 0 {
-		diags.AddError("Resources Not Found", fmt.Sprintf("The following IDs were not found: %v", allMissingIDs))
+	if handleMissing(data, &diags, "Resources Not Found", fmt.Sprintf("The following IDs were not found: %v", allMissingIDs), formatValues(allMissingIDs)) {
 		return nil, diags
 	}
 
@@ -240,6 +466,8 @@ func executeLookupIDs(ctx context.Context, data *ContentDataSourceModel, c *clie
 
 // executeLookupInt64Field handles lookup requests for int64 selector fields
 // (AMG artist/album/video IDs) using the provided setter to populate the request.
+// Values reported missing by the missing extractor are collected across batches
+// and described with label in the resulting error.
 func executeLookupInt64Field(
 	ctx context.Context,
 	field types.List,
@@ -247,6 +475,8 @@ func executeLookupInt64Field(
 	c *client.Client,
 	setter func(req *client.LookupRequest, batch []int64),
 	autoAlign bool,
+	label string,
+	missing func(notFoundErr *client.NotFoundError) []int64,
 ) ([]client.ContentResult, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var ids []int64
@@ -256,33 +486,53 @@ func executeLookupInt64Field(
 	}
 
 	var results []client.ContentResult
+	var allMissing []int64
 	baseRequest := buildLookupRequest(*data)
 
-	batches := common.ChunkInt64(ids, common.MaxLookupBatchSize)
-	for _, batch := range batches {
+	var reqs []client.LookupRequest
+	for _, batch := range common.ChunkInt64(ids, common.MaxLookupBatchSize) {
 		req := baseRequest
 		setter(&req, batch)
 		req.Limit = lookupLimitForBatch(data.Limit, len(batch), autoAlign)
+		reqs = append(reqs, req)
+	}
 
-		result, err := c.Lookup(ctx, req)
+	for _, batch := range runLookupBatches(ctx, c, reqs, common.LookupConcurrency) {
+		result, err := batch.result, batch.err
 		if err != nil {
-			diags.AddError("API Request Failed", err.Error())
+			var notFoundErr *client.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				allMissing = append(allMissing, missing(notFoundErr)...)
+				if result != nil {
+					results = append(results, result.Results...)
+				}
+				continue
+			}
+			diags.AddError(diagnostics.APIError(err))
 			return nil, diags
 		}
 		results = append(results, result.Results...)
 	}
 
+	if handleMissing(data, &diags, "Resources Not Found", fmt.Sprintf("The following %s were not found: %v", label, allMissing), formatValues(allMissing)) {
+		return nil, diags
+	}
+
 	return results, diags
 }
 
 // executeLookupStringField handles lookup requests for string selector fields
 // (UPCs, ISBNs, bundle IDs) using the provided setter to populate the request.
+// Values reported missing by the missing extractor are collected across batches
+// and described with label in the resulting error.
 func executeLookupStringField(
 	ctx context.Context,
 	field types.List,
 	data *ContentDataSourceModel,
 	c *client.Client,
 	setter func(req *client.LookupRequest, batch []string),
+	label string,
+	missing func(notFoundErr *client.NotFoundError) []string,
 ) ([]client.ContentResult, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var values []string
@@ -292,27 +542,73 @@ func executeLookupStringField(
 	}
 
 	var results []client.ContentResult
+	var allMissing []string
 	baseRequest := buildLookupRequest(*data)
 
-	batches := common.ChunkStrings(values, common.MaxLookupBatchSize)
-	for _, batch := range batches {
+	var reqs []client.LookupRequest
+	for _, batch := range common.ChunkStrings(values, common.MaxLookupBatchSize) {
 		req := baseRequest
 		setter(&req, batch)
 		req.Limit = lookupLimitForBatch(data.Limit, len(batch), false)
+		reqs = append(reqs, req)
+	}
 
-		result, err := c.Lookup(ctx, req)
+	for _, batch := range runLookupBatches(ctx, c, reqs, common.LookupConcurrency) {
+		result, err := batch.result, batch.err
 		if err != nil {
-			diags.AddError("API Request Failed", err.Error())
+			var notFoundErr *client.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				allMissing = append(allMissing, missing(notFoundErr)...)
+				if result != nil {
+					results = append(results, result.Results...)
+				}
+				continue
+			}
+			diags.AddError(diagnostics.APIError(err))
 			return nil, diags
 		}
 		results = append(results, result.Results...)
 	}
 
+	if handleMissing(data, &diags, "Resources Not Found", fmt.Sprintf("The following %s were not found: %v", label, allMissing), formatValues(allMissing)) {
+		return nil, diags
+	}
+
 	return results, diags
 }
 
+// handleMissing records the missing selector values on the model and reports
+// them according to on_missing: as an error that discards the results (the
+// default), as a warning, or not at all. It reports whether the lookup failed.
+func handleMissing(data *ContentDataSourceModel, diags *diag.Diagnostics, summary, detail string, missing []string) bool {
+	data.Missing = stringValues(missing)
+	if len(missing) == 0 {
+		return false
+	}
+
+	switch data.OnMissing.ValueString() {
+	case common.OnMissingIgnore:
+		return false
+	case common.OnMissingWarn:
+		diags.AddWarning(summary, detail)
+		return false
+	}
+
+	diags.AddError(summary, detail)
+	return true
+}
+
+// formatValues converts selector values to their string form.
+func formatValues[T any](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = fmt.Sprint(v)
+	}
+	return out
+}
+
 // executeSearch performs a search request using the term and optional parameters
-// from the data model.
+// from the data model, paginating through results when max_results is set.
 func executeSearch(ctx context.Context, data ContentDataSourceModel, c *client.Client) ([]client.ContentResult, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -345,80 +641,341 @@ func executeSearch(ctx context.Context, data ContentDataSourceModel, c *client.C
 		searchReq.Callback = data.Callback.ValueString()
 	}
 
+	if !data.MaxResults.IsNull() && !data.MaxResults.IsUnknown() {
+		var results []client.ContentResult
+		for result, err := range c.SearchAll(ctx, searchReq, data.MaxResults.ValueInt64()) {
+			if err != nil {
+				diags.AddError(diagnostics.APIError(err))
+				return nil, diags
+			}
+			results = append(results, result)
+		}
+		return results, diags
+	}
+
 	result, err := c.Search(ctx, searchReq)
 	if err != nil {
-		diags.AddError("API Request Failed", err.Error())
+		diags.AddError(diagnostics.APIError(err))
 		return nil, diags
 	}
 
 	return result.Results, diags
 }
 
-// mapResultsToModel converts API content results to Terraform model objects,
-// downloading and encoding artwork images.
-func mapResultsToModel(ctx context.Context, results []client.ContentResult) []ContentResultModel {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	var resultItems []ContentResultModel
+// artworkOptions controls how artwork is referenced and downloaded for results.
+type artworkOptions struct {
+	Download    bool
+	Size        int64
+	Format      string
+	Concurrency int
+}
 
-	for _, result := range results {
-		artworkURL := result.ArtworkURL
-		if artworkURL != "" && strings.HasSuffix(artworkURL, ".jpg") {
-			artworkURL = strings.TrimSuffix(artworkURL, ".jpg") + ".png"
+// artworkOptionsFromModel resolves the artwork settings from the data model,
+// applying the provider defaults for unset attributes.
+func artworkOptionsFromModel(data ContentDataSourceModel) artworkOptions {
+	opts := artworkOptions{
+		Download:    true,
+		Size:        common.DefaultArtworkSize,
+		Format:      common.DefaultArtworkFormat,
+		Concurrency: common.DefaultArtworkConcurrency,
+	}
+	if v := common.BoolPointer(data.DownloadArtwork); v != nil {
+		opts.Download = *v
+	}
+	if size := common.Int64Value(data.ArtworkSize); size > 0 {
+		opts.Size = size
+	}
+	if format := common.StringValue(data.ArtworkFormat); format != "" {
+		opts.Format = format
+	}
+	if concurrency := common.Int64Value(data.ArtworkConcurrency); concurrency > 0 {
+		opts.Concurrency = int(concurrency)
+	}
+	return opts
+}
+
+// sourceArtworkURL returns the largest artwork URL template available on a result.
+func sourceArtworkURL(result client.ContentResult) string {
+	for _, u := range []string{result.ArtworkURL, result.ArtworkURL600, result.ArtworkURL100, result.ArtworkURL60, result.ArtworkURL30} {
+		if u != "" {
+			return u
 		}
+	}
+	return ""
+}
 
-		var artworkBase64 string
-		if artworkURL != "" {
-			encoded, err := downloadAndEncodeImage(ctx, httpClient, artworkURL)
+// mapResultsToModel converts API content results to Terraform model objects,
+// optionally downloading and encoding artwork images concurrently.
+func mapResultsToModel(ctx context.Context, c *client.Client, results []client.ContentResult, opts artworkOptions) []ContentResultModel {
+	artworkURLs := make([]string, len(results))
+	for i, result := range results {
+		artworkURLs[i] = common.ArtworkURL(sourceArtworkURL(result), opts.Size, opts.Format)
+	}
+
+	artworkBase64 := make([]string, len(results))
+	if opts.Download {
+		var errs []error
+		artworkBase64, errs = fetchArtwork(ctx, c, artworkURLs, opts.Concurrency)
+		for i, err := range errs {
 			if err != nil {
 				tflog.Warn(ctx, "Failed to download artwork", map[string]any{
-					"track_name": result.TrackName,
+					"track_name": results[i].TrackName,
 					"error":      err.Error(),
 				})
-			} else {
-				artworkBase64 = encoded
 			}
 		}
+	}
+
+	var resultItems []ContentResultModel
 
+	for i, result := range results {
 		resultItem := ContentResultModel{
-			TrackName:        types.StringValue(result.TrackName),
-			BundleID:         types.StringValue(result.BundleID),
-			TrackID:          types.Int64Value(result.TrackID),
-			SellerName:       types.StringValue(result.SellerName),
-			Kind:             types.StringValue(result.Kind),
-			Description:      types.StringValue(result.Description),
-			ReleaseDate:      types.StringValue(result.ReleaseDate),
-			Price:            types.Float64Value(result.Price),
-			FormattedPrice:   types.StringValue(result.FormattedPrice),
-			Currency:         types.StringValue(result.Currency),
-			Version:          types.StringValue(result.Version),
-			PrimaryGenre:     types.StringValue(result.PrimaryGenre),
-			MinimumOSVersion: types.StringValue(result.MinimumOSVersion),
-			FileSizeBytes:    types.StringValue(result.FileSizeBytes),
-			ArtistViewURL:    types.StringValue(result.ArtistViewURL),
-			ArtworkURL:       types.StringValue(artworkURL),
-			ArtworkBase64:    types.StringValue(artworkBase64),
-			TrackViewURL:     types.StringValue(result.TrackViewURL),
-			AverageRating:    types.Float64Value(result.AverageRating),
-			RatingCount:      types.Int64Value(result.RatingCount),
+			TrackName:                   types.StringValue(result.TrackName),
+			BundleID:                    types.StringValue(result.BundleID),
+			TrackID:                     types.Int64Value(result.TrackID),
+			SellerName:                  types.StringValue(result.SellerName),
+			Kind:                        types.StringValue(result.Kind),
+			Description:                 types.StringValue(result.Description),
+			ReleaseDate:                 types.StringValue(result.ReleaseDate),
+			Price:                       types.Float64Value(result.Price),
+			FormattedPrice:              types.StringValue(result.FormattedPrice),
+			Currency:                    types.StringValue(result.Currency),
+			Version:                     types.StringValue(result.Version),
+			PrimaryGenre:                types.StringValue(result.PrimaryGenre),
+			MinimumOSVersion:            types.StringValue(result.MinimumOSVersion),
+			FileSizeBytes:               types.StringValue(result.FileSizeBytes),
+			ArtistViewURL:               types.StringValue(result.ArtistViewURL),
+			ArtworkURL:                  types.StringValue(artworkURLs[i]),
+			ArtworkBase64:               types.StringValue(artworkBase64[i]),
+			TrackViewURL:                types.StringValue(result.TrackViewURL),
+			AverageRating:               types.Float64Value(result.AverageRating),
+			RatingCount:                 types.Int64Value(result.RatingCount),
+			SupportedDevices:            stringValues(result.SupportedDevices),
+			Genres:                      stringValues(result.Genres),
+			Languages:                   stringValues(result.Languages),
+			WrapperType:                 types.StringValue(result.WrapperType),
+			ArtistID:                    types.Int64Value(result.ArtistID),
+			ArtistIDs:                   int64Values(result.ArtistIDs),
+			AMGArtistID:                 types.Int64Value(result.AMGArtistID),
+			ArtistName:                  types.StringValue(result.ArtistName),
+			ArtistType:                  types.StringValue(result.ArtistType),
+			ArtistLinkURL:               types.StringValue(result.ArtistLinkURL),
+			CollectionID:                types.Int64Value(result.CollectionID),
+			CollectionName:              types.StringValue(result.CollectionName),
+			CollectionCensoredName:      types.StringValue(result.CollectionCensoredName),
+			CollectionArtistID:          types.Int64Value(result.CollectionArtistID),
+			CollectionArtistName:        types.StringValue(result.CollectionArtistName),
+			CollectionViewURL:           types.StringValue(result.CollectionViewURL),
+			CollectionType:              types.StringValue(result.CollectionType),
+			TrackCensoredName:           types.StringValue(result.TrackCensoredName),
+			PreviewURL:                  types.StringValue(result.PreviewURL),
+			ArtworkURL30:                types.StringValue(result.ArtworkURL30),
+			ArtworkURL60:                types.StringValue(result.ArtworkURL60),
+			ArtworkURL100:               types.StringValue(result.ArtworkURL100),
+			ArtworkURL600:               types.StringValue(result.ArtworkURL600),
+			CollectionPrice:             types.Float64Value(result.CollectionPrice),
+			CollectionHDPrice:           types.Float64Value(result.CollectionHDPrice),
+			TrackPrice:                  types.Float64Value(result.TrackPrice),
+			TrackHDPrice:                types.Float64Value(result.TrackHDPrice),
+			TrackRentalPrice:            types.Float64Value(result.TrackRentalPrice),
+			TrackHDRentalPrice:          types.Float64Value(result.TrackHDRentalPrice),
+			Country:                     types.StringValue(result.Country),
+			CollectionExplicitness:      types.StringValue(result.CollectionExplicitness),
+			TrackExplicitness:           types.StringValue(result.TrackExplicitness),
+			ContentAdvisoryRating:       types.StringValue(result.ContentAdvisoryRating),
+			TrackContentRating:          types.StringValue(result.TrackContentRating),
+			Advisories:                  stringValues(result.Advisories),
+			DiscCount:                   types.Int64Value(result.DiscCount),
+			DiscNumber:                  types.Int64Value(result.DiscNumber),
+			TrackCount:                  types.Int64Value(result.TrackCount),
+			TrackNumber:                 types.Int64Value(result.TrackNumber),
+			TrackTimeMillis:             types.Int64Value(result.TrackTimeMillis),
+			PrimaryGenreID:              types.Int64Value(result.PrimaryGenreID),
+			GenreIDs:                    stringValues(result.GenreIDs),
+			ShortDescription:            types.StringValue(result.ShortDescription),
+			LongDescription:             types.StringValue(result.LongDescription),
+			Copyright:                   types.StringValue(result.Copyright),
+			IsStreamable:                types.BoolValue(result.IsStreamable),
+			HasITunesExtras:             types.BoolValue(result.HasITunesExtras),
+			FeedURL:                     types.StringValue(result.FeedURL),
+			EpisodeURL:                  types.StringValue(result.EpisodeURL),
+			EpisodeGUID:                 types.StringValue(result.EpisodeGUID),
+			EpisodeFileExtension:        types.StringValue(result.EpisodeFileExtension),
+			EpisodeContentType:          types.StringValue(result.EpisodeContentType),
+			SellerURL:                   types.StringValue(result.SellerURL),
+			ReleaseNotes:                types.StringValue(result.ReleaseNotes),
+			CurrentVersionReleaseDate:   types.StringValue(result.CurrentVersionReleaseDate),
+			ScreenshotURLs:              stringValues(result.ScreenshotURLs),
+			IPadScreenshotURLs:          stringValues(result.IPadScreenshotURLs),
+			AppleTVScreenshotURLs:       stringValues(result.AppleTVScreenshotURLs),
+			Features:                    stringValues(result.Features),
+			IsGameCenterEnabled:         types.BoolValue(result.IsGameCenterEnabled),
+			AverageRatingCurrentVersion: types.Float64Value(result.AverageRatingCurrentVersion),
+			RatingCountCurrentVersion:   types.Int64Value(result.RatingCountCurrentVersion),
+			Storefront:                  types.StringValue(result.Storefront),
 		}
 
-		resultItem.SupportedDevices = make([]types.String, len(result.SupportedDevices))
-		for i, device := range result.SupportedDevices {
-			resultItem.SupportedDevices[i] = types.StringValue(device)
-		}
+		resultItems = append(resultItems, resultItem)
+	}
 
-		resultItem.Genres = make([]types.String, len(result.Genres))
-		for i, genre := range result.Genres {
-			resultItem.Genres[i] = types.StringValue(genre)
-		}
+	return resultItems
+}
 
-		resultItem.Languages = make([]types.String, len(result.Languages))
-		for i, language := range result.Languages {
-			resultItem.Languages[i] = types.StringValue(language)
-		}
+// stringValues converts a slice of strings to Terraform string values.
+func stringValues(values []string) []types.String {
+	out := make([]types.String, len(values))
+	for i, v := range values {
+		out[i] = types.StringValue(v)
+	}
+	return out
+}
 
-		resultItems = append(resultItems, resultItem)
+// int64Values converts a slice of int64 values to Terraform int64 values.
+func int64Values(values []int64) []types.Int64 {
+	out := make([]types.Int64, len(values))
+	for i, v := range values {
+		out[i] = types.Int64Value(v)
 	}
+	return out
+}
 
-	return resultItems
+// typedResultModels holds the results split into the typed lists exposed by the
+// data source.
+type typedResultModels struct {
+	Artists     []ArtistModel
+	Collections []CollectionModel
+	Tracks      []TrackModel
+	Software    []SoftwareModel
+}
+
+// mapTypedResultsToModel decodes each result into its typed variant and maps it
+// into the matching typed list, preserving the original order within each list.
+func mapTypedResultsToModel(results []client.ContentResult) typedResultModels {
+	models := typedResultModels{
+		Artists:     []ArtistModel{},
+		Collections: []CollectionModel{},
+		Tracks:      []TrackModel{},
+		Software:    []SoftwareModel{},
+	}
+
+	for _, result := range client.TypedResults(results) {
+		switch r := result.(type) {
+		case *client.Artist:
+			models.Artists = append(models.Artists, ArtistModel{
+				ArtistID:       types.Int64Value(r.ArtistID),
+				AMGArtistID:    types.Int64Value(r.AMGArtistID),
+				ArtistName:     types.StringValue(r.ArtistName),
+				ArtistType:     types.StringValue(r.ArtistType),
+				ArtistLinkURL:  types.StringValue(r.ArtistLinkURL),
+				PrimaryGenre:   types.StringValue(r.PrimaryGenre),
+				PrimaryGenreID: types.Int64Value(r.PrimaryGenreID),
+			})
+
+		case *client.Collection:
+			models.Collections = append(models.Collections, CollectionModel{
+				WrapperType:            types.StringValue(r.WrapperType()),
+				CollectionID:           types.Int64Value(r.CollectionID),
+				CollectionName:         types.StringValue(r.CollectionName),
+				CollectionType:         types.StringValue(r.CollectionType),
+				CollectionViewURL:      types.StringValue(r.CollectionViewURL),
+				ArtistID:               types.Int64Value(r.ArtistID),
+				ArtistName:             types.StringValue(r.ArtistName),
+				ArtworkURL:             types.StringValue(r.ArtworkURL),
+				CollectionPrice:        types.Float64Value(r.CollectionPrice),
+				Currency:               types.StringValue(r.Currency),
+				CollectionExplicitness: types.StringValue(r.CollectionExplicitness),
+				TrackCount:             types.Int64Value(r.TrackCount),
+				ReleaseDate:            types.StringValue(r.ReleaseDate),
+				PrimaryGenre:           types.StringValue(r.PrimaryGenre),
+			})
+
+		case *client.Audiobook:
+			models.Collections = append(models.Collections, CollectionModel{
+				WrapperType:            types.StringValue(r.WrapperType()),
+				CollectionID:           types.Int64Value(r.CollectionID),
+				CollectionName:         types.StringValue(r.CollectionName),
+				CollectionType:         types.StringNull(),
+				CollectionViewURL:      types.StringValue(r.CollectionViewURL),
+				ArtistID:               types.Int64Value(r.ArtistID),
+				ArtistName:             types.StringValue(r.ArtistName),
+				ArtworkURL:             types.StringValue(r.ArtworkURL),
+				CollectionPrice:        types.Float64Value(r.CollectionPrice),
+				Currency:               types.StringValue(r.Currency),
+				CollectionExplicitness: types.StringValue(r.CollectionExplicitness),
+				TrackCount:             types.Int64Value(r.TrackCount),
+				ReleaseDate:            types.StringValue(r.ReleaseDate),
+				PrimaryGenre:           types.StringValue(r.PrimaryGenre),
+			})
+
+		case *client.Track:
+			models.Tracks = append(models.Tracks, TrackModel{
+				WrapperType:       types.StringValue(r.WrapperType()),
+				Kind:              types.StringValue(r.Kind),
+				TrackID:           types.Int64Value(r.TrackID),
+				TrackName:         types.StringValue(r.TrackName),
+				TrackViewURL:      types.StringValue(r.TrackViewURL),
+				ArtistID:          types.Int64Value(r.ArtistID),
+				ArtistName:        types.StringValue(r.ArtistName),
+				CollectionID:      types.Int64Value(r.CollectionID),
+				CollectionName:    types.StringValue(r.CollectionName),
+				TrackNumber:       types.Int64Value(r.TrackNumber),
+				TrackCount:        types.Int64Value(r.TrackCount),
+				DiscNumber:        types.Int64Value(r.DiscNumber),
+				TrackTimeMillis:   types.Int64Value(r.TrackTimeMillis),
+				PreviewURL:        types.StringValue(r.PreviewURL),
+				EpisodeURL:        types.StringNull(),
+				TrackPrice:        types.Float64Value(r.TrackPrice),
+				Currency:          types.StringValue(r.Currency),
+				TrackExplicitness: types.StringValue(r.TrackExplicitness),
+				ReleaseDate:       types.StringValue(r.ReleaseDate),
+			})
+
+		case *client.PodcastEpisode:
+			// Episode rows carry no artist, pricing, explicitness, or track
+			// numbering, so those attributes are left null.
+			models.Tracks = append(models.Tracks, TrackModel{
+				WrapperType:       types.StringValue(r.WrapperType()),
+				Kind:              types.StringValue("podcast-episode"),
+				TrackID:           types.Int64Value(r.TrackID),
+				TrackName:         types.StringValue(r.TrackName),
+				TrackViewURL:      types.StringValue(r.TrackViewURL),
+				ArtistID:          types.Int64Null(),
+				ArtistName:        types.StringNull(),
+				CollectionID:      types.Int64Value(r.CollectionID),
+				CollectionName:    types.StringValue(r.CollectionName),
+				TrackNumber:       types.Int64Null(),
+				TrackCount:        types.Int64Null(),
+				DiscNumber:        types.Int64Null(),
+				TrackTimeMillis:   types.Int64Value(r.TrackTimeMillis),
+				PreviewURL:        types.StringNull(),
+				EpisodeURL:        types.StringValue(r.EpisodeURL),
+				TrackPrice:        types.Float64Null(),
+				Currency:          types.StringNull(),
+				TrackExplicitness: types.StringNull(),
+				ReleaseDate:       types.StringValue(r.ReleaseDate),
+			})
+
+		case *client.Software:
+			models.Software = append(models.Software, SoftwareModel{
+				TrackID:                   types.Int64Value(r.TrackID),
+				TrackName:                 types.StringValue(r.TrackName),
+				TrackViewURL:              types.StringValue(r.TrackViewURL),
+				BundleID:                  types.StringValue(r.BundleID),
+				SellerName:                types.StringValue(r.SellerName),
+				Version:                   types.StringValue(r.Version),
+				MinimumOSVersion:          types.StringValue(r.MinimumOSVersion),
+				FileSizeBytes:             types.StringValue(r.FileSizeBytes),
+				Price:                     types.Float64Value(r.Price),
+				FormattedPrice:            types.StringValue(r.FormattedPrice),
+				Currency:                  types.StringValue(r.Currency),
+				ReleaseDate:               types.StringValue(r.ReleaseDate),
+				CurrentVersionReleaseDate: types.StringValue(r.CurrentVersionReleaseDate),
+				PrimaryGenre:              types.StringValue(r.PrimaryGenre),
+				AverageRating:             types.Float64Value(r.AverageRating),
+				RatingCount:               types.Int64Value(r.RatingCount),
+			})
+		}
+	}
+
+	return models
 }
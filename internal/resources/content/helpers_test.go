@@ -5,49 +5,21 @@ package content
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
-)
 
-func TestParseAppStoreURL(t *testing.T) {
-	tests := []struct {
-		url             string
-		expectedID      int64
-		expectedCountry string
-	}{
-		{
-			url:             "https://apps.apple.com/us/app/pages/id361309726",
-			expectedID:      361309726,
-			expectedCountry: "us",
-		},
-		{
-			url:             "https://apps.apple.com/gb/app/microsoft-word/id462054704",
-			expectedID:      462054704,
-			expectedCountry: "gb",
-		},
-		{
-			url:             "https://apps.apple.com/de/app/some-app/id999",
-			expectedID:      999,
-			expectedCountry: "de",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.url, func(t *testing.T) {
-			trackID, country := parseAppStoreURL(tt.url)
-			if trackID != tt.expectedID {
-				t.Errorf("expected track ID %d, got %d", tt.expectedID, trackID)
-			}
-			if country != tt.expectedCountry {
-				t.Errorf("expected country %q, got %q", tt.expectedCountry, country)
-			}
-		})
-	}
-}
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/client"
+	"github.com/neilmartin83/terraform-provider-itunessearchapi/internal/testing/fakeitunes"
+)
 
 func TestLookupLimitForBatch_NullLimit(t *testing.T) {
 	limit := types.Int64Null()
@@ -123,8 +95,8 @@ func TestDownloadAndEncodeImage_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	httpClient := &http.Client{}
-	encoded, err := downloadAndEncodeImage(context.Background(), httpClient, server.URL+"/image.png")
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	encoded, err := downloadAndEncodeImage(context.Background(), c, server.URL+"/image.png")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -139,9 +111,640 @@ func TestDownloadAndEncodeImage_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	httpClient := &http.Client{}
-	_, err := downloadAndEncodeImage(context.Background(), httpClient, server.URL+"/missing.png")
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	_, err := downloadAndEncodeImage(context.Background(), c, server.URL+"/missing.png")
 	if err == nil {
 		t.Fatal("expected error for 404 response")
 	}
 }
+
+func TestMapResultsToModel_CollectionFields(t *testing.T) {
+	results := []client.ContentResult{
+		{
+			WrapperType:       "track",
+			Kind:              "song",
+			ArtistID:          909253,
+			ArtistName:        "Jack Johnson",
+			CollectionID:      1469577723,
+			CollectionName:    "Jack Johnson and Friends",
+			TrackNumber:       3,
+			TrackTimeMillis:   210743,
+			PreviewURL:        "https://example.com/preview.m4a",
+			TrackExplicitness: "notExplicit",
+			Genres:            client.GenreList{"Rock"},
+			ArtistIDs:         []int64{1, 2},
+		},
+	}
+
+	models := mapResultsToModel(context.Background(), client.NewClient(), results, artworkOptions{Download: false})
+	if len(models) != 1 {
+		t.Fatalf("expected 1 model, got %d", len(models))
+	}
+
+	m := models[0]
+	if m.WrapperType.ValueString() != "track" {
+		t.Errorf("expected wrapper type %q, got %q", "track", m.WrapperType.ValueString())
+	}
+	if m.ArtistName.ValueString() != "Jack Johnson" || m.CollectionName.ValueString() != "Jack Johnson and Friends" {
+		t.Errorf("unexpected artist/collection names: %q %q", m.ArtistName.ValueString(), m.CollectionName.ValueString())
+	}
+	if m.CollectionID.ValueInt64() != 1469577723 || m.TrackNumber.ValueInt64() != 3 {
+		t.Errorf("unexpected collection ID/track number: %d %d", m.CollectionID.ValueInt64(), m.TrackNumber.ValueInt64())
+	}
+	if len(m.Genres) != 1 || m.Genres[0].ValueString() != "Rock" {
+		t.Errorf("unexpected genres: %v", m.Genres)
+	}
+	if len(m.ArtistIDs) != 2 || m.ArtistIDs[1].ValueInt64() != 2 {
+		t.Errorf("unexpected artist IDs: %v", m.ArtistIDs)
+	}
+	if len(m.ScreenshotURLs) != 0 {
+		t.Errorf("expected empty screenshot URLs, got %v", m.ScreenshotURLs)
+	}
+}
+
+func TestMapTypedResultsToModel(t *testing.T) {
+	results := []client.ContentResult{
+		{WrapperType: "artist", ArtistID: 909253, ArtistName: "Jack Johnson"},
+		{WrapperType: "collection", CollectionID: 1469577723, CollectionName: "Jack Johnson and Friends"},
+		{WrapperType: "track", Kind: "song", TrackID: 1469577741, TrackName: "Upside Down"},
+		{WrapperType: "software", TrackID: 361309726, BundleID: "com.apple.Pages"},
+		{WrapperType: "podcastEpisode", TrackID: 1000, EpisodeURL: "https://example.com/ep.mp3"},
+		{WrapperType: "audiobook", CollectionID: 2000, CollectionExplicitness: "notExplicit", TrackCount: 12},
+	}
+
+	typed := mapTypedResultsToModel(results)
+
+	if len(typed.Artists) != 1 || typed.Artists[0].ArtistName.ValueString() != "Jack Johnson" {
+		t.Errorf("unexpected artists: %+v", typed.Artists)
+	}
+	if len(typed.Collections) != 2 || typed.Collections[0].CollectionID.ValueInt64() != 1469577723 {
+		t.Fatalf("unexpected collections: %+v", typed.Collections)
+	}
+	audiobook := typed.Collections[1]
+	if audiobook.CollectionExplicitness.ValueString() != "notExplicit" || audiobook.TrackCount.ValueInt64() != 12 {
+		t.Errorf("expected audiobook explicitness and track count, got %+v", audiobook)
+	}
+	if !audiobook.CollectionType.IsNull() {
+		t.Errorf("expected null audiobook collection type, got %v", audiobook.CollectionType)
+	}
+	if len(typed.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %d", len(typed.Tracks))
+	}
+	if !typed.Tracks[0].EpisodeURL.IsNull() {
+		t.Errorf("expected null episode URL on a track, got %v", typed.Tracks[0].EpisodeURL)
+	}
+	episode := typed.Tracks[1]
+	if episode.EpisodeURL.ValueString() != "https://example.com/ep.mp3" {
+		t.Errorf("expected podcast episode URL, got %q", episode.EpisodeURL.ValueString())
+	}
+	if !episode.ArtistID.IsNull() || !episode.ArtistName.IsNull() || !episode.TrackPrice.IsNull() ||
+		!episode.Currency.IsNull() || !episode.TrackExplicitness.IsNull() {
+		t.Errorf("expected null attributes the episode row does not carry, got %+v", episode)
+	}
+	if len(typed.Software) != 1 || typed.Software[0].BundleID.ValueString() != "com.apple.Pages" {
+		t.Errorf("unexpected software: %+v", typed.Software)
+	}
+}
+
+func TestMapTypedResultsToModel_Empty(t *testing.T) {
+	typed := mapTypedResultsToModel(nil)
+	if typed.Artists == nil || typed.Collections == nil || typed.Tracks == nil || typed.Software == nil {
+		t.Error("expected empty, non-nil typed lists")
+	}
+}
+
+func TestExecuteSearch_MaxResultsPaginates(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		w.WriteHeader(http.StatusOK)
+		switch offset {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"results":[{"trackId":1},{"trackId":2}]}`)
+		case "2":
+			_, _ = fmt.Fprint(w, `{"results":[{"trackId":2},{"trackId":3}]}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"results":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	data := ContentDataSourceModel{
+		Term:       types.StringValue("test"),
+		Limit:      types.Int64Value(2),
+		MaxResults: types.Int64Value(10),
+	}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	results, diags := executeSearch(context.Background(), data, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 unique results, got %d", len(results))
+	}
+	if len(offsets) != 3 {
+		t.Errorf("expected 3 page requests, got %v", offsets)
+	}
+}
+
+func TestArtworkOptionsFromModel_Defaults(t *testing.T) {
+	opts := artworkOptionsFromModel(ContentDataSourceModel{})
+	if !opts.Download {
+		t.Error("expected artwork download to default to true")
+	}
+	if opts.Size != 512 || opts.Format != "png" {
+		t.Errorf("expected 512/png defaults, got %d/%s", opts.Size, opts.Format)
+	}
+}
+
+func TestMapResultsToModel_ArtworkOptions(t *testing.T) {
+	var downloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "fake-image-data")
+	}))
+	defer server.Close()
+
+	results := []client.ContentResult{
+		{TrackName: "Song", ArtworkURL100: server.URL + "/image/source/100x100bb.jpg"},
+	}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	models := mapResultsToModel(context.Background(), c, results, artworkOptions{Download: false, Size: 60, Format: "webp"})
+	if downloads != 0 {
+		t.Errorf("expected no downloads when disabled, got %d", downloads)
+	}
+	if got := models[0].ArtworkURL.ValueString(); got != server.URL+"/image/source/60x60bb.webp" {
+		t.Errorf("unexpected artwork URL %q", got)
+	}
+	if models[0].ArtworkBase64.ValueString() != "" {
+		t.Error("expected empty artwork_base64 when download is disabled")
+	}
+
+	models = mapResultsToModel(context.Background(), c, results, artworkOptions{Download: true, Size: 1024, Format: "jpg", Concurrency: 1})
+	if downloads != 1 {
+		t.Errorf("expected 1 download, got %d", downloads)
+	}
+	if models[0].ArtworkBase64.ValueString() == "" {
+		t.Error("expected artwork_base64 to be populated")
+	}
+}
+
+func TestFetchArtwork_PreservesOrderWithConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, r.URL.Path)
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", "", server.URL + "/b", server.URL + "/c", server.URL + "/d"}
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	encoded, errs := fetchArtwork(context.Background(), c, urls, 2)
+
+	expected := []string{"/a", "", "/b", "/c", "/d"}
+	for i, want := range expected {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error at %d: %v", i, errs[i])
+		}
+		got := ""
+		if encoded[i] != "" {
+			decoded, err := base64.StdEncoding.DecodeString(encoded[i])
+			if err != nil {
+				t.Fatalf("invalid base64 at %d: %v", i, err)
+			}
+			got = string(decoded)
+		}
+		if got != want {
+			t.Errorf("expected %q at %d, got %q", want, i, got)
+		}
+	}
+	if maxInFlight := atomic.LoadInt32(&maxInFlight); maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent downloads, got %d", maxInFlight)
+	}
+}
+
+func TestFetchArtwork_RetriesServerErrorsAndReportsFailures(t *testing.T) {
+	var flakyCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if atomic.AddInt32(&flakyCalls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, "image")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	encoded, errs := fetchArtwork(context.Background(), c, []string{server.URL + "/flaky", server.URL + "/missing"}, 4)
+
+	if errs[0] != nil || encoded[0] == "" {
+		t.Errorf("expected flaky download to succeed after retry, got error %v", errs[0])
+	}
+	if errs[1] == nil {
+		t.Error("expected error for missing artwork")
+	}
+}
+
+// newBatchLookupServer returns a server that answers id lookups with every
+// requested ID except those in missing. Every batch is delayed, the first most
+// so that later batches complete first, and the peak number of in-flight requests is recorded.
+func newBatchLookupServer(t *testing.T, missing map[int64]bool, peak *int32) *httptest.Server {
+	t.Helper()
+	var inFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(peak)
+			if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+				break
+			}
+		}
+
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		if ids[0] == "1" {
+			time.Sleep(100 * time.Millisecond)
+		} else {
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		var rows []string
+		for _, raw := range ids {
+			id, _ := strconv.ParseInt(raw, 10, 64)
+			if !missing[id] {
+				rows = append(rows, fmt.Sprintf(`{"trackId":%d}`, id))
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"results":[%s]}`, strings.Join(rows, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func idListModel(t *testing.T, n int) ContentDataSourceModel {
+	t.Helper()
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	list, diags := types.ListValueFrom(context.Background(), types.Int64Type, ids)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return ContentDataSourceModel{IDs: list}
+}
+
+func TestExecuteLookupIDs_ConcurrentBatchesPreserveOrder(t *testing.T) {
+	var peak int32
+	server := newBatchLookupServer(t, nil, &peak)
+	data := idListModel(t, 450)
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	results, diags := executeLookupIDs(context.Background(), &data, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(results) != 450 {
+		t.Fatalf("expected 450 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.TrackID != int64(i+1) {
+			t.Fatalf("expected track %d at index %d, got %d", i+1, i, r.TrackID)
+		}
+	}
+	if atomic.LoadInt32(&peak) < 2 {
+		t.Errorf("expected batches to overlap, peak in-flight was %d", atomic.LoadInt32(&peak))
+	}
+}
+
+func TestExecuteLookupIDs_ConcurrentBatchesAggregateMissing(t *testing.T) {
+	var peak int32
+	server := newBatchLookupServer(t, map[int64]bool{150: true, 250: true, 420: true}, &peak)
+	data := idListModel(t, 450)
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	_, diags := executeLookupIDs(context.Background(), &data, c)
+	if !diags.HasError() {
+		t.Fatal("expected Resources Not Found error")
+	}
+	if diags[0].Summary() != "Resources Not Found" {
+		t.Errorf("expected summary %q, got %q", "Resources Not Found", diags[0].Summary())
+	}
+	if !strings.Contains(diags[0].Detail(), "[150 250 420]") {
+		t.Errorf("expected missing IDs in input order, got %q", diags[0].Detail())
+	}
+}
+
+func TestRunLookupBatches_ReportsFirstFailureInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("bundleId") {
+		case "slow":
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, `{"results":[{"bundleId":"slow"}]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	reqs := []client.LookupRequest{
+		{BundleIDs: []string{"slow"}},
+		{BundleIDs: []string{"bad"}},
+		{BundleIDs: []string{"later"}},
+	}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	outcomes := runLookupBatches(context.Background(), c, reqs, 2)
+	if outcomes[0].err != nil || len(outcomes[0].result.Results) != 1 {
+		t.Errorf("expected first batch to succeed, got %+v", outcomes[0])
+	}
+	if outcomes[1].err == nil || !strings.Contains(outcomes[1].err.Error(), "400") {
+		t.Errorf("expected second batch to fail with 400, got %v", outcomes[1].err)
+	}
+	if outcomes[2].err == nil {
+		t.Error("expected third batch to report the failure")
+	}
+}
+
+func TestExecuteLookup_ReportsMissingBundleIDs(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	bundleIDs, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"com.apple.Pages", "com.apple.Typo"})
+	data := ContentDataSourceModel{BundleIDs: bundleIDs}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	_, diags := executeLookup(context.Background(), &data, c)
+	if !diags.HasError() {
+		t.Fatal("expected Resources Not Found error")
+	}
+	expected := "The following bundle IDs were not found: [com.apple.Typo]"
+	if diags[0].Summary() != "Resources Not Found" || diags[0].Detail() != expected {
+		t.Errorf("expected %q, got %q: %q", expected, diags[0].Summary(), diags[0].Detail())
+	}
+}
+
+func TestExecuteLookup_ReportsMissingAMGArtistIDs(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+
+	amgIDs, _ := types.ListValueFrom(context.Background(), types.Int64Type, []int64{468749, 42})
+	data := ContentDataSourceModel{AMGArtistIDs: amgIDs}
+
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+	_, diags := executeLookup(context.Background(), &data, c)
+	if !diags.HasError() {
+		t.Fatal("expected Resources Not Found error")
+	}
+	expected := "The following AMG artist IDs were not found: [42]"
+	if diags[0].Detail() != expected {
+		t.Errorf("expected %q, got %q", expected, diags[0].Detail())
+	}
+}
+
+func TestExecuteLookupIDs_OnMissing(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	tests := []struct {
+		onMissing   types.String
+		wantError   bool
+		wantWarning bool
+		wantResults int
+	}{
+		{types.StringNull(), true, false, 0},
+		{types.StringValue("error"), true, false, 0},
+		{types.StringValue("warn"), false, true, 1},
+		{types.StringValue("ignore"), false, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.onMissing.String(), func(t *testing.T) {
+			ids, _ := types.ListValueFrom(context.Background(), types.Int64Type, []int64{361309726, 999})
+			data := ContentDataSourceModel{IDs: ids, OnMissing: tt.onMissing}
+
+			results, diags := executeLookupIDs(context.Background(), &data, c)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %t, got %v", tt.wantError, diags)
+			}
+			if (diags.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("expected warning %t, got %v", tt.wantWarning, diags)
+			}
+			if len(results) != tt.wantResults {
+				t.Errorf("expected %d results, got %d", tt.wantResults, len(results))
+			}
+			if len(data.Missing) != 1 || data.Missing[0].ValueString() != "999" {
+				t.Errorf("expected missing [999], got %v", data.Missing)
+			}
+		})
+	}
+}
+
+func TestExecuteLookupAppStoreURLs_OnMissingWarn(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	missingURL := "https://apps.apple.com/us/app/delisted/id999"
+	urls, _ := types.ListValueFrom(context.Background(), types.StringType, []string{
+		"https://apps.apple.com/us/app/pages/id361309726",
+		missingURL,
+	})
+	data := ContentDataSourceModel{AppStoreURLs: urls, OnMissing: types.StringValue("warn")}
+
+	results, diags := executeLookupAppStoreURLs(context.Background(), &data, c)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if len(results) != 1 || results[0].TrackID != 361309726 {
+		t.Errorf("expected Pages to be returned, got %+v", results)
+	}
+	if len(data.Missing) != 1 || data.Missing[0].ValueString() != missingURL {
+		t.Errorf("expected missing [%s], got %v", missingURL, data.Missing)
+	}
+}
+
+func TestExecuteLookup_CountriesFanOut(t *testing.T) {
+	server := fakeitunes.New(
+		fakeitunes.Item{WrapperType: "software", TrackID: 1, BundleID: "com.example.regional", Countries: []string{"us", "gb"}},
+		fakeitunes.Item{WrapperType: "software", TrackID: 2, BundleID: "com.example.global"},
+	)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	bundleIDs, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"com.example.regional", "com.example.global"})
+	countries, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"us", "gb", "jp"})
+	data := ContentDataSourceModel{BundleIDs: bundleIDs, Countries: countries}
+
+	results, diags := executeLookup(context.Background(), &data, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results across storefronts, got %d", len(results))
+	}
+	var storefronts []string
+	for _, r := range results {
+		storefronts = append(storefronts, r.Storefront)
+	}
+	if strings.Join(storefronts, ",") != "us,us,gb,gb,jp" {
+		t.Errorf("expected results tagged us,us,gb,gb,jp, got %v", storefronts)
+	}
+
+	if len(data.Availability) != 2 {
+		t.Fatalf("expected availability for 2 items, got %d", len(data.Availability))
+	}
+	regional := data.Availability[0]
+	if regional.Item.ValueString() != "com.example.regional" {
+		t.Errorf("expected first item com.example.regional, got %s", regional.Item)
+	}
+	if len(regional.MissingIn) != 1 || regional.MissingIn[0].ValueString() != "jp" {
+		t.Errorf("expected regional app missing in jp, got %v", regional.MissingIn)
+	}
+	if !regional.Countries["gb"].ValueBool() || regional.Countries["jp"].ValueBool() {
+		t.Errorf("unexpected availability matrix: %v", regional.Countries)
+	}
+	if len(data.Availability[1].AvailableIn) != 3 {
+		t.Errorf("expected global app in 3 storefronts, got %v", data.Availability[1].AvailableIn)
+	}
+	if len(data.Missing) != 0 {
+		t.Errorf("expected nothing missing everywhere, got %v", data.Missing)
+	}
+}
+
+func TestExecuteLookup_CountriesMissingEverywhere(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.Item{WrapperType: "software", TrackID: 1, BundleID: "com.example.app", Countries: []string{"us"}})
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	bundleIDs, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"com.example.app", "com.example.delisted"})
+	countries, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"us", "gb"})
+	data := ContentDataSourceModel{BundleIDs: bundleIDs, Countries: countries}
+
+	_, diags := executeLookup(context.Background(), &data, c)
+	if !diags.HasError() {
+		t.Fatal("expected Resources Not Found error")
+	}
+	expected := "The following items were not found in any requested storefront: [com.example.delisted]"
+	if diags[0].Detail() != expected {
+		t.Errorf("expected %q, got %q", expected, diags[0].Detail())
+	}
+}
+
+func TestExecuteLookupAppStoreURLs_MixedCountries(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	urls, _ := types.ListValueFrom(context.Background(), types.StringType, []string{
+		"https://apps.apple.com/us/app/pages/id361309726",
+		"https://apps.apple.com/gb/app/keynote/id361285480",
+		"https://apps.apple.com/us/app/keynote/id361285480",
+	})
+	data := ContentDataSourceModel{AppStoreURLs: urls}
+
+	results, diags := executeLookupAppStoreURLs(context.Background(), &data, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Storefront != "us" || results[2].Storefront != "gb" {
+		t.Errorf("expected us results before gb results, got %s and %s", results[0].Storefront, results[2].Storefront)
+	}
+	if !data.Country.IsNull() {
+		t.Errorf("expected country to stay unset for mixed storefronts, got %s", data.Country)
+	}
+	if len(data.Availability) != 3 || !data.Availability[1].Countries["gb"].ValueBool() {
+		t.Errorf("unexpected availability: %+v", data.Availability)
+	}
+}
+
+func TestExecuteLookupAppStoreURLs_AppleMediaURLs(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL, DefaultCountry: "gb"})
+
+	urls, _ := types.ListValueFrom(context.Background(), types.StringType, []string{
+		"https://apps.apple.com/app/id361309726",
+		"https://music.apple.com/gb/album/in-between-dreams/1469577723",
+		"https://books.apple.com/gb/book/missing/id999999",
+	})
+	data := ContentDataSourceModel{AppStoreURLs: urls, OnMissing: types.StringValue("warn")}
+
+	results, diags := executeLookupAppStoreURLs(context.Background(), &data, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if data.Country.ValueString() != "gb" {
+		t.Errorf("expected URL without a country to use the default country, got %s", data.Country)
+	}
+	if len(data.Missing) != 1 || data.Missing[0].ValueString() != "https://books.apple.com/gb/book/missing/id999999" {
+		t.Errorf("expected the book URL to be missing, got %v", data.Missing)
+	}
+	expected := []struct{ id, media, entity string }{
+		{"361309726", "software", "software"},
+		{"1469577723", "music", "album"},
+		{"999999", "ebook", "ebook"},
+	}
+	requests := server.Requests()
+	if len(requests) != len(expected) {
+		t.Fatalf("expected one lookup per inferred entity, got %d", len(requests))
+	}
+	for i, want := range expected {
+		query := requests[i].Query()
+		if query.Get("id") != want.id || query.Get("media") != want.media || query.Get("entity") != want.entity {
+			t.Errorf("request %d: expected id=%s media=%s entity=%s, got %s", i, want.id, want.media, want.entity, query.Encode())
+		}
+	}
+}
+
+func TestExecuteLookupAppStoreURLs_EntityOverridesInference(t *testing.T) {
+	server := fakeitunes.New(fakeitunes.DefaultCatalog()...)
+	defer server.Close()
+	c := client.NewClientWithConfig(client.Config{BaseURL: server.URL})
+
+	urls, _ := types.ListValueFrom(context.Background(), types.StringType, []string{
+		"https://music.apple.com/us/album/in-between-dreams/1469577723",
+		"https://music.apple.com/us/artist/jack-johnson/909253",
+	})
+	data := ContentDataSourceModel{AppStoreURLs: urls, Media: types.StringValue("music"), Entity: types.StringValue("song")}
+
+	if _, diags := executeLookupAppStoreURLs(context.Background(), &data, c); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected one lookup with the configured entity, got %d", len(requests))
+	}
+	if query := requests[0].Query(); query.Get("id") != "1469577723,909253" || query.Get("entity") != "song" {
+		t.Errorf("unexpected lookup query %s", query.Encode())
+	}
+}
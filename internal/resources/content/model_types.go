@@ -10,53 +10,206 @@ import (
 
 // ContentDataSourceModel describes the data source data model.
 type ContentDataSourceModel struct {
-	Timeouts     timeouts.Value       `tfsdk:"timeouts"`
-	AppStoreURLs types.List           `tfsdk:"app_store_urls"`
-	Term         types.String         `tfsdk:"term"`
-	IDs          types.List           `tfsdk:"ids"`
-	AMGArtistIDs types.List           `tfsdk:"amg_artist_ids"`
-	AMGAlbumIDs  types.List           `tfsdk:"amg_album_ids"`
-	AMGVideoIDs  types.List           `tfsdk:"amg_video_ids"`
-	UPCs         types.List           `tfsdk:"upcs"`
-	ISBNs        types.List           `tfsdk:"isbns"`
-	BundleIDs    types.List           `tfsdk:"bundle_ids"`
-	Country      types.String         `tfsdk:"country"`
-	Media        types.String         `tfsdk:"media"`
-	Entity       types.String         `tfsdk:"entity"`
-	Limit        types.Int64          `tfsdk:"limit"`
-	Sort         types.String         `tfsdk:"sort"`
-	Attribute    types.String         `tfsdk:"attribute"`
-	Lang         types.String         `tfsdk:"lang"`
-	Version      types.Int64          `tfsdk:"version"`
-	Explicit     types.Bool           `tfsdk:"explicit"`
-	Offset       types.Int64          `tfsdk:"offset"`
-	Callback     types.String         `tfsdk:"callback"`
-	Results      []ContentResultModel `tfsdk:"results"`
+	Timeouts           timeouts.Value       `tfsdk:"timeouts"`
+	AppStoreURLs       types.List           `tfsdk:"app_store_urls"`
+	Term               types.String         `tfsdk:"term"`
+	IDs                types.List           `tfsdk:"ids"`
+	AMGArtistIDs       types.List           `tfsdk:"amg_artist_ids"`
+	AMGAlbumIDs        types.List           `tfsdk:"amg_album_ids"`
+	AMGVideoIDs        types.List           `tfsdk:"amg_video_ids"`
+	UPCs               types.List           `tfsdk:"upcs"`
+	ISBNs              types.List           `tfsdk:"isbns"`
+	BundleIDs          types.List           `tfsdk:"bundle_ids"`
+	Country            types.String         `tfsdk:"country"`
+	Countries          types.List           `tfsdk:"countries"`
+	Media              types.String         `tfsdk:"media"`
+	Entity             types.String         `tfsdk:"entity"`
+	Limit              types.Int64          `tfsdk:"limit"`
+	Sort               types.String         `tfsdk:"sort"`
+	Attribute          types.String         `tfsdk:"attribute"`
+	Lang               types.String         `tfsdk:"lang"`
+	Version            types.Int64          `tfsdk:"version"`
+	Explicit           types.Bool           `tfsdk:"explicit"`
+	Offset             types.Int64          `tfsdk:"offset"`
+	Callback           types.String         `tfsdk:"callback"`
+	MaxResults         types.Int64          `tfsdk:"max_results"`
+	DownloadArtwork    types.Bool           `tfsdk:"download_artwork"`
+	ArtworkSize        types.Int64          `tfsdk:"artwork_size"`
+	ArtworkFormat      types.String         `tfsdk:"artwork_format"`
+	ArtworkConcurrency types.Int64          `tfsdk:"artwork_concurrency"`
+	OnMissing          types.String         `tfsdk:"on_missing"`
+	Missing            []types.String       `tfsdk:"missing"`
+	Availability       []AvailabilityModel  `tfsdk:"availability"`
+	Results            []ContentResultModel `tfsdk:"results"`
+	Artists            []ArtistModel        `tfsdk:"artists"`
+	Collections        []CollectionModel    `tfsdk:"collections"`
+	Tracks             []TrackModel         `tfsdk:"tracks"`
+	Software           []SoftwareModel      `tfsdk:"software"`
 }
 
 // ContentResultModel describes a single content search result.
 type ContentResultModel struct {
-	TrackName        types.String   `tfsdk:"track_name"`
-	BundleID         types.String   `tfsdk:"bundle_id"`
-	TrackID          types.Int64    `tfsdk:"track_id"`
-	SellerName       types.String   `tfsdk:"seller_name"`
-	Kind             types.String   `tfsdk:"kind"`
-	Description      types.String   `tfsdk:"description"`
-	ReleaseDate      types.String   `tfsdk:"release_date"`
-	Price            types.Float64  `tfsdk:"price"`
-	FormattedPrice   types.String   `tfsdk:"formatted_price"`
-	Currency         types.String   `tfsdk:"currency"`
-	Version          types.String   `tfsdk:"version"`
-	PrimaryGenre     types.String   `tfsdk:"primary_genre"`
-	MinimumOSVersion types.String   `tfsdk:"minimum_os_version"`
-	FileSizeBytes    types.String   `tfsdk:"file_size_bytes"`
-	ArtistViewURL    types.String   `tfsdk:"artist_view_url"`
-	ArtworkURL       types.String   `tfsdk:"artwork_url"`
-	ArtworkBase64    types.String   `tfsdk:"artwork_base64"`
-	TrackViewURL     types.String   `tfsdk:"track_view_url"`
-	SupportedDevices []types.String `tfsdk:"supported_devices"`
-	Genres           []types.String `tfsdk:"genres"`
-	Languages        []types.String `tfsdk:"languages"`
-	AverageRating    types.Float64  `tfsdk:"average_rating"`
-	RatingCount      types.Int64    `tfsdk:"rating_count"`
+	TrackName                   types.String   `tfsdk:"track_name"`
+	BundleID                    types.String   `tfsdk:"bundle_id"`
+	TrackID                     types.Int64    `tfsdk:"track_id"`
+	SellerName                  types.String   `tfsdk:"seller_name"`
+	Kind                        types.String   `tfsdk:"kind"`
+	Description                 types.String   `tfsdk:"description"`
+	ReleaseDate                 types.String   `tfsdk:"release_date"`
+	Price                       types.Float64  `tfsdk:"price"`
+	FormattedPrice              types.String   `tfsdk:"formatted_price"`
+	Currency                    types.String   `tfsdk:"currency"`
+	Version                     types.String   `tfsdk:"version"`
+	PrimaryGenre                types.String   `tfsdk:"primary_genre"`
+	MinimumOSVersion            types.String   `tfsdk:"minimum_os_version"`
+	FileSizeBytes               types.String   `tfsdk:"file_size_bytes"`
+	ArtistViewURL               types.String   `tfsdk:"artist_view_url"`
+	ArtworkURL                  types.String   `tfsdk:"artwork_url"`
+	ArtworkBase64               types.String   `tfsdk:"artwork_base64"`
+	TrackViewURL                types.String   `tfsdk:"track_view_url"`
+	SupportedDevices            []types.String `tfsdk:"supported_devices"`
+	Genres                      []types.String `tfsdk:"genres"`
+	Languages                   []types.String `tfsdk:"languages"`
+	AverageRating               types.Float64  `tfsdk:"average_rating"`
+	RatingCount                 types.Int64    `tfsdk:"rating_count"`
+	WrapperType                 types.String   `tfsdk:"wrapper_type"`
+	ArtistID                    types.Int64    `tfsdk:"artist_id"`
+	ArtistIDs                   []types.Int64  `tfsdk:"artist_ids"`
+	AMGArtistID                 types.Int64    `tfsdk:"amg_artist_id"`
+	ArtistName                  types.String   `tfsdk:"artist_name"`
+	ArtistType                  types.String   `tfsdk:"artist_type"`
+	ArtistLinkURL               types.String   `tfsdk:"artist_link_url"`
+	CollectionID                types.Int64    `tfsdk:"collection_id"`
+	CollectionName              types.String   `tfsdk:"collection_name"`
+	CollectionCensoredName      types.String   `tfsdk:"collection_censored_name"`
+	CollectionArtistID          types.Int64    `tfsdk:"collection_artist_id"`
+	CollectionArtistName        types.String   `tfsdk:"collection_artist_name"`
+	CollectionViewURL           types.String   `tfsdk:"collection_view_url"`
+	CollectionType              types.String   `tfsdk:"collection_type"`
+	TrackCensoredName           types.String   `tfsdk:"track_censored_name"`
+	PreviewURL                  types.String   `tfsdk:"preview_url"`
+	ArtworkURL30                types.String   `tfsdk:"artwork_url_30"`
+	ArtworkURL60                types.String   `tfsdk:"artwork_url_60"`
+	ArtworkURL100               types.String   `tfsdk:"artwork_url_100"`
+	ArtworkURL600               types.String   `tfsdk:"artwork_url_600"`
+	CollectionPrice             types.Float64  `tfsdk:"collection_price"`
+	CollectionHDPrice           types.Float64  `tfsdk:"collection_hd_price"`
+	TrackPrice                  types.Float64  `tfsdk:"track_price"`
+	TrackHDPrice                types.Float64  `tfsdk:"track_hd_price"`
+	TrackRentalPrice            types.Float64  `tfsdk:"track_rental_price"`
+	TrackHDRentalPrice          types.Float64  `tfsdk:"track_hd_rental_price"`
+	Country                     types.String   `tfsdk:"country"`
+	CollectionExplicitness      types.String   `tfsdk:"collection_explicitness"`
+	TrackExplicitness           types.String   `tfsdk:"track_explicitness"`
+	ContentAdvisoryRating       types.String   `tfsdk:"content_advisory_rating"`
+	TrackContentRating          types.String   `tfsdk:"track_content_rating"`
+	Advisories                  []types.String `tfsdk:"advisories"`
+	DiscCount                   types.Int64    `tfsdk:"disc_count"`
+	DiscNumber                  types.Int64    `tfsdk:"disc_number"`
+	TrackCount                  types.Int64    `tfsdk:"track_count"`
+	TrackNumber                 types.Int64    `tfsdk:"track_number"`
+	TrackTimeMillis             types.Int64    `tfsdk:"track_time_millis"`
+	PrimaryGenreID              types.Int64    `tfsdk:"primary_genre_id"`
+	GenreIDs                    []types.String `tfsdk:"genre_ids"`
+	ShortDescription            types.String   `tfsdk:"short_description"`
+	LongDescription             types.String   `tfsdk:"long_description"`
+	Copyright                   types.String   `tfsdk:"copyright"`
+	IsStreamable                types.Bool     `tfsdk:"is_streamable"`
+	HasITunesExtras             types.Bool     `tfsdk:"has_itunes_extras"`
+	FeedURL                     types.String   `tfsdk:"feed_url"`
+	EpisodeURL                  types.String   `tfsdk:"episode_url"`
+	EpisodeGUID                 types.String   `tfsdk:"episode_guid"`
+	EpisodeFileExtension        types.String   `tfsdk:"episode_file_extension"`
+	EpisodeContentType          types.String   `tfsdk:"episode_content_type"`
+	SellerURL                   types.String   `tfsdk:"seller_url"`
+	ReleaseNotes                types.String   `tfsdk:"release_notes"`
+	CurrentVersionReleaseDate   types.String   `tfsdk:"current_version_release_date"`
+	ScreenshotURLs              []types.String `tfsdk:"screenshot_urls"`
+	IPadScreenshotURLs          []types.String `tfsdk:"ipad_screenshot_urls"`
+	AppleTVScreenshotURLs       []types.String `tfsdk:"appletv_screenshot_urls"`
+	Features                    []types.String `tfsdk:"features"`
+	IsGameCenterEnabled         types.Bool     `tfsdk:"is_game_center_enabled"`
+	AverageRatingCurrentVersion types.Float64  `tfsdk:"average_rating_current_version"`
+	RatingCountCurrentVersion   types.Int64    `tfsdk:"rating_count_current_version"`
+	Storefront                  types.String   `tfsdk:"storefront"`
+}
+
+// AvailabilityModel describes which requested storefronts returned a lookup item.
+type AvailabilityModel struct {
+	Item        types.String          `tfsdk:"item"`
+	Countries   map[string]types.Bool `tfsdk:"countries"`
+	AvailableIn []types.String        `tfsdk:"available_in"`
+	MissingIn   []types.String        `tfsdk:"missing_in"`
+}
+
+// ArtistModel describes an artist row from the results.
+type ArtistModel struct {
+	ArtistID       types.Int64  `tfsdk:"artist_id"`
+	AMGArtistID    types.Int64  `tfsdk:"amg_artist_id"`
+	ArtistName     types.String `tfsdk:"artist_name"`
+	ArtistType     types.String `tfsdk:"artist_type"`
+	ArtistLinkURL  types.String `tfsdk:"artist_link_url"`
+	PrimaryGenre   types.String `tfsdk:"primary_genre"`
+	PrimaryGenreID types.Int64  `tfsdk:"primary_genre_id"`
+}
+
+// CollectionModel describes a collection or audiobook row from the results.
+type CollectionModel struct {
+	WrapperType            types.String  `tfsdk:"wrapper_type"`
+	CollectionID           types.Int64   `tfsdk:"collection_id"`
+	CollectionName         types.String  `tfsdk:"collection_name"`
+	CollectionType         types.String  `tfsdk:"collection_type"`
+	CollectionViewURL      types.String  `tfsdk:"collection_view_url"`
+	ArtistID               types.Int64   `tfsdk:"artist_id"`
+	ArtistName             types.String  `tfsdk:"artist_name"`
+	ArtworkURL             types.String  `tfsdk:"artwork_url"`
+	CollectionPrice        types.Float64 `tfsdk:"collection_price"`
+	Currency               types.String  `tfsdk:"currency"`
+	CollectionExplicitness types.String  `tfsdk:"collection_explicitness"`
+	TrackCount             types.Int64   `tfsdk:"track_count"`
+	ReleaseDate            types.String  `tfsdk:"release_date"`
+	PrimaryGenre           types.String  `tfsdk:"primary_genre"`
+}
+
+// TrackModel describes a track or podcast episode row from the results.
+type TrackModel struct {
+	WrapperType       types.String  `tfsdk:"wrapper_type"`
+	Kind              types.String  `tfsdk:"kind"`
+	TrackID           types.Int64   `tfsdk:"track_id"`
+	TrackName         types.String  `tfsdk:"track_name"`
+	TrackViewURL      types.String  `tfsdk:"track_view_url"`
+	ArtistID          types.Int64   `tfsdk:"artist_id"`
+	ArtistName        types.String  `tfsdk:"artist_name"`
+	CollectionID      types.Int64   `tfsdk:"collection_id"`
+	CollectionName    types.String  `tfsdk:"collection_name"`
+	TrackNumber       types.Int64   `tfsdk:"track_number"`
+	TrackCount        types.Int64   `tfsdk:"track_count"`
+	DiscNumber        types.Int64   `tfsdk:"disc_number"`
+	TrackTimeMillis   types.Int64   `tfsdk:"track_time_millis"`
+	PreviewURL        types.String  `tfsdk:"preview_url"`
+	EpisodeURL        types.String  `tfsdk:"episode_url"`
+	TrackPrice        types.Float64 `tfsdk:"track_price"`
+	Currency          types.String  `tfsdk:"currency"`
+	TrackExplicitness types.String  `tfsdk:"track_explicitness"`
+	ReleaseDate       types.String  `tfsdk:"release_date"`
+}
+
+// SoftwareModel describes a software row from the results.
+type SoftwareModel struct {
+	TrackID                   types.Int64   `tfsdk:"track_id"`
+	TrackName                 types.String  `tfsdk:"track_name"`
+	TrackViewURL              types.String  `tfsdk:"track_view_url"`
+	BundleID                  types.String  `tfsdk:"bundle_id"`
+	SellerName                types.String  `tfsdk:"seller_name"`
+	Version                   types.String  `tfsdk:"version"`
+	MinimumOSVersion          types.String  `tfsdk:"minimum_os_version"`
+	FileSizeBytes             types.String  `tfsdk:"file_size_bytes"`
+	Price                     types.Float64 `tfsdk:"price"`
+	FormattedPrice            types.String  `tfsdk:"formatted_price"`
+	Currency                  types.String  `tfsdk:"currency"`
+	ReleaseDate               types.String  `tfsdk:"release_date"`
+	CurrentVersionReleaseDate types.String  `tfsdk:"current_version_release_date"`
+	PrimaryGenre              types.String  `tfsdk:"primary_genre"`
+	AverageRating             types.Float64 `tfsdk:"average_rating"`
+	RatingCount               types.Int64   `tfsdk:"rating_count"`
 }
@@ -0,0 +1,54 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package acctest provides helpers shared by the acceptance tests, so that
+// they can record or replay HTTP fixtures recorded from the live API.
+package acctest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// The fixture environment variables read by the provider and the client
+// acceptance tests. They match provider.EnvFixtureMode and
+// provider.EnvFixtureDir, which this package cannot import without creating an
+// import cycle for the client package's tests.
+const (
+	envFixtureMode = "ITUNES_FIXTURE_MODE"
+	envFixtureDir  = "ITUNES_FIXTURE_DIR"
+)
+
+// FixtureDir returns the absolute path of the repository's testdata/fixtures,
+// where fixtures recorded from the live API are kept.
+func FixtureDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "testdata", "fixtures")
+}
+
+// Fixtures returns the fixture mode and directory for the test from
+// ITUNES_FIXTURE_MODE and ITUNES_FIXTURE_DIR. When a mode is set without a
+// directory, FixtureDir is used and ITUNES_FIXTURE_DIR is set for the duration
+// of the test, so the provider under test picks it up too.
+func Fixtures(t *testing.T) (mode, dir string) {
+	t.Helper()
+
+	mode, dir = os.Getenv(envFixtureMode), os.Getenv(envFixtureDir)
+	if mode != "" && dir == "" {
+		dir = FixtureDir()
+		t.Setenv(envFixtureDir, dir)
+	}
+	return mode, dir
+}
+
+// PreCheck prepares the environment for a provider acceptance test. It is
+// meant to be used as the test case's PreCheck.
+func PreCheck(t *testing.T) {
+	t.Helper()
+
+	if mode, dir := Fixtures(t); mode != "" {
+		t.Logf("Using %s fixtures in %s", mode, dir)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeitunes
+
+import "maps"
+
+// Item is a single catalog entry served by the fake API. Identifier fields are
+// used for lookup selectors and parent/child relationships; Fields carries any
+// additional response fields, keyed by their iTunes JSON name.
+type Item struct {
+	// WrapperType is the iTunes wrapperType, such as "track", "collection",
+	// "artist", or "software".
+	WrapperType    string
+	Kind           string
+	TrackID        int64
+	TrackName      string
+	CollectionID   int64
+	CollectionName string
+	ArtistID       int64
+	ArtistName     string
+	AMGArtistID    int64
+	BundleID       string
+
+	// AMGAlbumID, AMGVideoID, UPC, and ISBN are matched by lookups but, as with
+	// the real API, are not included in response rows.
+	AMGAlbumID int64
+	AMGVideoID int64
+	UPC        string
+	ISBN       string
+
+	// Media and Entity are the search media type (e.g. "music") and entity
+	// (e.g. "song", "album", "musicArtist") the item belongs to.
+	Media  string
+	Entity string
+
+	// Countries lists the storefronts the item is available in. An empty list
+	// means the item is available everywhere.
+	Countries []string
+
+	Fields map[string]any
+}
+
+// row returns the JSON response row for the item.
+func (it Item) row() map[string]any {
+	row := map[string]any{}
+	set := func(key string, v any) {
+		switch v := v.(type) {
+		case string:
+			if v != "" {
+				row[key] = v
+			}
+		case int64:
+			if v != 0 {
+				row[key] = v
+			}
+		}
+	}
+
+	set("wrapperType", it.WrapperType)
+	set("kind", it.Kind)
+	set("trackId", it.TrackID)
+	set("trackName", it.TrackName)
+	set("collectionId", it.CollectionID)
+	set("collectionName", it.CollectionName)
+	set("artistId", it.ArtistID)
+	set("artistName", it.ArtistName)
+	set("amgArtistId", it.AMGArtistID)
+	set("bundleId", it.BundleID)
+	maps.Copy(row, it.Fields)
+	return row
+}
+
+// primaryID returns the identifier that a lookup by id matches for the item.
+func (it Item) primaryID() int64 {
+	switch it.WrapperType {
+	case "artist":
+		return it.ArtistID
+	case "collection", "audiobook":
+		return it.CollectionID
+	}
+	return it.TrackID
+}
+
+// DefaultCatalog returns a small catalog covering apps, music, and books that
+// exercises every lookup selector.
+func DefaultCatalog() []Item {
+	return []Item{
+		{
+			WrapperType: "software",
+			Kind:        "software",
+			TrackID:     361309726,
+			TrackName:   "Pages",
+			ArtistID:    284417353,
+			ArtistName:  "Apple",
+			BundleID:    "com.apple.Pages",
+			Media:       "software",
+			Entity:      "software",
+			Fields: map[string]any{
+				"version":          "14.4",
+				"minimumOsVersion": "17.0",
+				"sellerName":       "Apple Inc.",
+				"price":            0.0,
+				"formattedPrice":   "Free",
+				"currency":         "USD",
+				"primaryGenreName": "Productivity",
+				"genres":           []string{"Productivity", "Business"},
+				"artworkUrl512":    "https://is1-ssl.mzstatic.com/image/thumb/Purple/v4/pages/512x512bb.jpg",
+				"trackViewUrl":     "https://apps.apple.com/us/app/pages/id361309726",
+			},
+		},
+		{
+			WrapperType: "software",
+			Kind:        "software",
+			TrackID:     361285480,
+			TrackName:   "Keynote",
+			ArtistID:    284417353,
+			ArtistName:  "Apple",
+			BundleID:    "com.apple.Keynote",
+			Media:       "software",
+			Entity:      "software",
+			Fields: map[string]any{
+				"version":          "14.4",
+				"minimumOsVersion": "17.0",
+				"sellerName":       "Apple Inc.",
+				"price":            0.0,
+				"formattedPrice":   "Free",
+				"currency":         "USD",
+				"primaryGenreName": "Productivity",
+				"genres":           []string{"Productivity", "Business"},
+				"artworkUrl512":    "https://is1-ssl.mzstatic.com/image/thumb/Purple/v4/keynote/512x512bb.jpg",
+				"trackViewUrl":     "https://apps.apple.com/us/app/keynote/id361285480",
+			},
+		},
+		{
+			WrapperType: "artist",
+			ArtistID:    909253,
+			ArtistName:  "Jack Johnson",
+			AMGArtistID: 468749,
+			Media:       "music",
+			Entity:      "musicArtist",
+			Fields: map[string]any{
+				"artistType":       "Artist",
+				"artistLinkUrl":    "https://music.apple.com/us/artist/jack-johnson/909253",
+				"primaryGenreName": "Rock",
+				"primaryGenreId":   21,
+			},
+		},
+		{
+			WrapperType:    "collection",
+			CollectionID:   1469577723,
+			CollectionName: "In Between Dreams",
+			ArtistID:       909253,
+			ArtistName:     "Jack Johnson",
+			AMGArtistID:    468749,
+			AMGAlbumID:     15175,
+			UPC:            "602547924636",
+			Media:          "music",
+			Entity:         "album",
+			Fields: map[string]any{
+				"collectionType":   "Album",
+				"trackCount":       14,
+				"releaseDate":      "2005-03-01T08:00:00Z",
+				"primaryGenreName": "Rock",
+				"collectionPrice":  9.99,
+				"currency":         "USD",
+			},
+		},
+		{
+			WrapperType:    "track",
+			Kind:           "song",
+			TrackID:        1469577741,
+			TrackName:      "Better Together",
+			CollectionID:   1469577723,
+			CollectionName: "In Between Dreams",
+			ArtistID:       909253,
+			ArtistName:     "Jack Johnson",
+			Media:          "music",
+			Entity:         "song",
+			Fields: map[string]any{
+				"trackNumber":      1,
+				"discNumber":       1,
+				"trackTimeMillis":  207679,
+				"trackPrice":       1.29,
+				"currency":         "USD",
+				"primaryGenreName": "Rock",
+			},
+		},
+		{
+			WrapperType:    "track",
+			Kind:           "song",
+			TrackID:        1469577752,
+			TrackName:      "Banana Pancakes",
+			CollectionID:   1469577723,
+			CollectionName: "In Between Dreams",
+			ArtistID:       909253,
+			ArtistName:     "Jack Johnson",
+			Media:          "music",
+			Entity:         "song",
+			Fields: map[string]any{
+				"trackNumber":      3,
+				"discNumber":       1,
+				"trackTimeMillis":  191867,
+				"trackPrice":       1.29,
+				"currency":         "USD",
+				"primaryGenreName": "Rock",
+			},
+		},
+		{
+			WrapperType: "track",
+			Kind:        "music-video",
+			TrackID:     1445738051,
+			TrackName:   "Upside Down",
+			ArtistID:    909253,
+			ArtistName:  "Jack Johnson",
+			AMGVideoID:  17120,
+			Media:       "musicVideo",
+			Entity:      "musicVideo",
+			Countries:   []string{"us"},
+		},
+		{
+			Kind:       "ebook",
+			TrackID:    1435728082,
+			TrackName:  "The Martian",
+			ArtistID:   2122513,
+			ArtistName: "Andy Weir",
+			ISBN:       "9780553418026",
+			Media:      "ebook",
+			Entity:     "ebook",
+			Fields: map[string]any{
+				"price":          8.99,
+				"formattedPrice": "$8.99",
+				"currency":       "USD",
+				"genres":         []string{"Sci-Fi & Fantasy"},
+			},
+		},
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeitunes provides an in-process fake of the iTunes Search API for
+// tests. It serves /search and /lookup from a seeded catalog and can inject
+// rate-limit and server error responses.
+package fakeitunes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Default and maximum result limits applied by the real API.
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// DefaultCountry is the storefront used when a request does not specify one.
+const DefaultCountry = "us"
+
+// Fault is an error response returned instead of serving a request.
+type Fault struct {
+	StatusCode int
+	// RetryAfter is sent as the Retry-After header when non-empty.
+	RetryAfter string
+}
+
+// Server is a running fake iTunes Search API.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	items    []Item
+	faults   []Fault
+	requests []*url.URL
+}
+
+// New starts a fake API server seeded with items. Callers must Close it.
+func New(items ...Item) *Server {
+	s := &Server{items: slices.Clone(items)}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Add appends items to the catalog.
+func (s *Server) Add(items ...Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, items...)
+}
+
+// InjectFaults queues error responses, which are returned in order by the next
+// requests before normal serving resumes.
+func (s *Server) InjectFaults(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, faults...)
+}
+
+// RateLimit makes the next n requests fail with HTTP 429 and the given
+// Retry-After value. An empty retryAfter omits the header.
+func (s *Server) RateLimit(n int, retryAfter string) {
+	for range n {
+		s.InjectFaults(Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: retryAfter})
+	}
+}
+
+// FailWithStatus makes the next n requests fail with the given status code,
+// typically a 5xx server error.
+func (s *Server) FailWithStatus(n, statusCode int) {
+	for range n {
+		s.InjectFaults(Fault{StatusCode: statusCode})
+	}
+}
+
+// Requests returns the URLs of every request received, including faulted ones.
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// RequestCount returns the number of requests received.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL)
+	var fault *Fault
+	if len(s.faults) > 0 {
+		fault = &s.faults[0]
+		s.faults = s.faults[1:]
+	}
+	items := slices.Clone(s.items)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		w.WriteHeader(fault.StatusCode)
+		return
+	}
+
+	q := r.URL.Query()
+	var (
+		rows []map[string]any
+		err  error
+	)
+	switch r.URL.Path {
+	case "/search":
+		rows, err = search(items, q)
+	case "/lookup":
+		rows, err = lookup(items, q)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"errorMessage": err.Error()}, "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"resultCount": len(rows), "results": rows}, q.Get("callback"))
+}
+
+// invalidValueError is the error message the API returns for a bad or missing parameter.
+type invalidValueError string
+
+// Error implements the error interface.
+func (e invalidValueError) Error() string {
+	return fmt.Sprintf("Invalid value(s) for key(s): [%s]", string(e))
+}
+
+// search implements /search: every word of term must appear in the item's
+// track, collection, or artist name, optionally filtered by media, entity, and
+// country, then paged with offset and limit.
+func search(items []Item, q url.Values) ([]map[string]any, error) {
+	term := strings.ToLower(strings.TrimSpace(q.Get("term")))
+	if term == "" {
+		return nil, invalidValueError("term")
+	}
+	media := q.Get("media")
+	entity := q.Get("entity")
+	country := countryParam(q)
+
+	limit, err := intParam(q, "limit", DefaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := intParam(q, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit = min(limit, MaxLimit)
+
+	words := strings.Fields(term)
+	var matched []Item
+	for _, it := range items {
+		if !it.availableIn(country) {
+			continue
+		}
+		if media != "" && media != "all" && it.Media != media {
+			continue
+		}
+		if entity != "" && it.Entity != entity {
+			continue
+		}
+		haystack := strings.ToLower(it.TrackName + " " + it.CollectionName + " " + it.ArtistName)
+		if !containsAll(haystack, words) {
+			continue
+		}
+		matched = append(matched, it)
+	}
+
+	if offset >= len(matched) {
+		return []map[string]any{}, nil
+	}
+	matched = matched[offset:min(len(matched), offset+limit)]
+
+	rows := make([]map[string]any, 0, len(matched))
+	for _, it := range matched {
+		rows = append(rows, it.row())
+	}
+	return rows, nil
+}
+
+// lookup implements /lookup: each selector value matches items in request
+// order, and an entity expands matched artists and collections into their
+// related items, capped per parent by limit.
+func lookup(items []Item, q url.Values) ([]map[string]any, error) {
+	selectors := []struct {
+		key   string
+		match func(Item, string) bool
+	}{
+		{"id", func(it Item, v string) bool { return strconv.FormatInt(it.primaryID(), 10) == v }},
+		{"amgArtistId", func(it Item, v string) bool {
+			return it.WrapperType == "artist" && strconv.FormatInt(it.AMGArtistID, 10) == v
+		}},
+		{"amgAlbumId", func(it Item, v string) bool { return it.AMGAlbumID != 0 && strconv.FormatInt(it.AMGAlbumID, 10) == v }},
+		{"amgVideoId", func(it Item, v string) bool { return it.AMGVideoID != 0 && strconv.FormatInt(it.AMGVideoID, 10) == v }},
+		{"upc", func(it Item, v string) bool { return it.UPC != "" && it.UPC == v }},
+		{"isbn", func(it Item, v string) bool { return it.ISBN != "" && it.ISBN == v }},
+		{"bundleId", func(it Item, v string) bool { return it.BundleID != "" && strings.EqualFold(it.BundleID, v) }},
+	}
+
+	country := countryParam(q)
+	entity := q.Get("entity")
+	limit, err := intParam(q, "limit", DefaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	limit = min(limit, MaxLimit)
+
+	selectorSet := false
+	var parents []int
+	for _, sel := range selectors {
+		raw := q.Get(sel.key)
+		if raw == "" {
+			continue
+		}
+		selectorSet = true
+		for v := range strings.SplitSeq(raw, ",") {
+			for i, it := range items {
+				if it.availableIn(country) && sel.match(it, v) && !slices.Contains(parents, i) {
+					parents = append(parents, i)
+				}
+			}
+		}
+	}
+	if !selectorSet {
+		return nil, invalidValueError("id")
+	}
+
+	rows := []map[string]any{}
+	for _, p := range parents {
+		parent := items[p]
+		rows = append(rows, parent.row())
+		if entity == "" {
+			continue
+		}
+
+		related := 0
+		for i, it := range items {
+			if related >= limit {
+				break
+			}
+			if i == p || it.Entity != entity || !it.availableIn(country) || !parent.parentOf(it) {
+				continue
+			}
+			rows = append(rows, it.row())
+			related++
+		}
+	}
+	return rows, nil
+}
+
+// parentOf reports whether child belongs to the artist or collection it.
+func (it Item) parentOf(child Item) bool {
+	switch it.WrapperType {
+	case "artist":
+		return it.ArtistID != 0 && child.ArtistID == it.ArtistID
+	case "collection":
+		return it.CollectionID != 0 && child.CollectionID == it.CollectionID
+	}
+	return false
+}
+
+// availableIn reports whether the item is sold in the storefront.
+func (it Item) availableIn(country string) bool {
+	return len(it.Countries) == 0 || slices.Contains(it.Countries, country)
+}
+
+// countryParam returns the lowercased country parameter or the default storefront.
+func countryParam(q url.Values) string {
+	if c := q.Get("country"); c != "" {
+		return strings.ToLower(c)
+	}
+	return DefaultCountry
+}
+
+// intParam parses a non-negative integer query parameter.
+func intParam(q url.Values, key string, fallback int) (int, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, invalidValueError(key)
+	}
+	return n, nil
+}
+
+// containsAll reports whether every word appears in s.
+func containsAll(s string, words []string) bool {
+	for _, w := range words {
+		if !strings.Contains(s, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeJSON writes v as JSON, wrapped in a JSONP callback when one is given.
+func writeJSON(w http.ResponseWriter, status int, v any, callback string) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.WriteHeader(status)
+	if callback == "" {
+		_, _ = w.Write(body)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n\n\n%s(%s);", callback, body)
+}
@@ -0,0 +1,197 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeitunes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type response struct {
+	ResultCount int              `json:"resultCount"`
+	Results     []map[string]any `json:"results"`
+}
+
+func get(t *testing.T, s *Server, path string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(s.URL + path)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp, body
+}
+
+func getResults(t *testing.T, s *Server, path string) response {
+	t.Helper()
+	resp, body := get(t, s, path)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	var r response
+	if err := json.Unmarshal(body, &r); err != nil {
+		t.Fatalf("failed to decode %s: %v", body, err)
+	}
+	return r
+}
+
+func TestLookup_Selectors(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	tests := []struct {
+		query    string
+		expected string
+		field    string
+	}{
+		{"id=361309726", "Pages", "trackName"},
+		{"id=909253", "Jack Johnson", "artistName"},
+		{"bundleId=com.apple.Keynote", "Keynote", "trackName"},
+		{"upc=602547924636", "In Between Dreams", "collectionName"},
+		{"isbn=9780553418026", "The Martian", "trackName"},
+		{"amgArtistId=468749", "artist", "wrapperType"},
+		{"amgAlbumId=15175", "In Between Dreams", "collectionName"},
+		{"amgVideoId=17120", "Upside Down", "trackName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := getResults(t, s, "/lookup?"+tt.query)
+			if r.ResultCount != 1 {
+				t.Fatalf("expected 1 result, got %d", r.ResultCount)
+			}
+			if r.Results[0][tt.field] != tt.expected {
+				t.Errorf("expected %s %q, got %v", tt.field, tt.expected, r.Results[0][tt.field])
+			}
+		})
+	}
+}
+
+func TestLookup_MultipleValuesPreserveOrder(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	r := getResults(t, s, "/lookup?bundleId=com.apple.Keynote,com.apple.Pages,com.example.Missing")
+	if r.ResultCount != 2 {
+		t.Fatalf("expected 2 results, got %d", r.ResultCount)
+	}
+	if r.Results[0]["trackName"] != "Keynote" || r.Results[1]["trackName"] != "Pages" {
+		t.Errorf("unexpected order: %v", r.Results)
+	}
+}
+
+func TestLookup_EntityExpansionAndLimit(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	r := getResults(t, s, "/lookup?id=1469577723&entity=song")
+	if r.ResultCount != 3 {
+		t.Fatalf("expected collection plus 2 songs, got %d", r.ResultCount)
+	}
+	if r.Results[0]["wrapperType"] != "collection" || r.Results[1]["kind"] != "song" {
+		t.Errorf("unexpected rows: %v", r.Results)
+	}
+
+	r = getResults(t, s, "/lookup?id=909253&entity=song&limit=1")
+	if r.ResultCount != 2 {
+		t.Fatalf("expected artist plus 1 song, got %d", r.ResultCount)
+	}
+}
+
+func TestLookup_CountryAvailability(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	if r := getResults(t, s, "/lookup?amgVideoId=17120&country=gb"); r.ResultCount != 0 {
+		t.Errorf("expected no results in gb, got %d", r.ResultCount)
+	}
+}
+
+func TestLookup_NoSelector(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	resp, body := get(t, s, "/lookup?country=us")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), "errorMessage") {
+		t.Errorf("expected errorMessage, got %s", body)
+	}
+}
+
+func TestSearch_TermMediaAndEntity(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	if r := getResults(t, s, "/search?term=jack+johnson"); r.ResultCount != 5 {
+		t.Errorf("expected 5 results for all media, got %d", r.ResultCount)
+	}
+	if r := getResults(t, s, "/search?term=jack+johnson&media=music&entity=song"); r.ResultCount != 2 {
+		t.Errorf("expected 2 songs, got %d", r.ResultCount)
+	}
+	if r := getResults(t, s, "/search?term=pages&media=software"); r.ResultCount != 1 {
+		t.Errorf("expected 1 app, got %d", r.ResultCount)
+	}
+}
+
+func TestSearch_LimitAndOffset(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	first := getResults(t, s, "/search?term=jack&limit=2")
+	second := getResults(t, s, "/search?term=jack&limit=2&offset=2")
+	if first.ResultCount != 2 || second.ResultCount != 2 {
+		t.Fatalf("expected 2 results per page, got %d and %d", first.ResultCount, second.ResultCount)
+	}
+	if first.Results[0]["artistId"] == nil || first.Results[1]["collectionId"] != second.Results[0]["collectionId"] {
+		t.Errorf("expected consecutive pages, got %v then %v", first.Results, second.Results)
+	}
+
+	if r := getResults(t, s, "/search?term=jack&offset=100"); r.ResultCount != 0 {
+		t.Errorf("expected empty page past the end, got %d", r.ResultCount)
+	}
+}
+
+func TestSearch_JSONPCallback(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	_, body := get(t, s, "/search?term=pages&callback=handle")
+	trimmed := strings.TrimSpace(string(body))
+	if !strings.HasPrefix(trimmed, "handle({") || !strings.HasSuffix(trimmed, "});") {
+		t.Errorf("expected JSONP wrapper, got %s", body)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	s := New(DefaultCatalog()...)
+	defer s.Close()
+
+	s.RateLimit(1, "2")
+	s.FailWithStatus(1, http.StatusServiceUnavailable)
+
+	resp, _ := get(t, s, "/search?term=pages")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Errorf("expected 429 with Retry-After 2, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	resp, _ = get(t, s, "/search?term=pages")
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", resp.StatusCode)
+	}
+	getResults(t, s, "/search?term=pages")
+
+	if s.RequestCount() != 3 {
+		t.Errorf("expected 3 requests, got %d", s.RequestCount())
+	}
+}